@@ -0,0 +1,255 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"jiraiya/pkg/client"
+)
+
+// newFlagSet creates a subcommand's flag set with a usage line and summary.
+func newFlagSet(name, args, summary string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: jiraiya %s [flags] %s\n\n%s\n\n", name, args, summary)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func runSubmit(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("submit", "<file>", "Submits a release from a YAML or JSON file; - reads standard input.")
+	cfg.bind(fs, false)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected one file, got %d arguments", fs.NArg())
+	}
+
+	sub, err := readSubmission(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	res, err := cfg.client().SubmitRelease(ctx, sub, client.WriteOptions{})
+	if err != nil {
+		return err
+	}
+	for _, w := range res.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s %s changed from %q to %q\n", w.JiraID, w.Field, w.Old, w.New)
+	}
+	fmt.Fprintf(os.Stderr, "submitted %s %s with %d jiras\n", sub.Release.Platform, sub.Release.Version, len(sub.Changes))
+	return nil
+}
+
+// readSubmission parses a release submission. Files ending in .json are read
+// as JSON; anything else, including standard input, as YAML, which also
+// accepts JSON. YAML keys are the JSON field names.
+func readSubmission(path string) (client.ReleaseSubmission, error) {
+	var sub client.ReleaseSubmission
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return sub, fmt.Errorf("read submission: %w", err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&sub); err != nil {
+			return sub, fmt.Errorf("parse %s: %w", path, err)
+		}
+		return sub, nil
+	}
+
+	// Round-trip through JSON so the struct's json tags name the keys.
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return sub, fmt.Errorf("parse %s: %w", path, err)
+	}
+	js, err := json.Marshal(doc)
+	if err != nil {
+		return sub, fmt.Errorf("parse %s: %w", path, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&sub); err != nil {
+		return sub, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return sub, nil
+}
+
+func runDiff(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("diff", "<from> <to>", "Lists the jiras shipped after from up to and including to.")
+	cfg.bind(fs, true)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expected <from> and <to>, got %d arguments", fs.NArg())
+	}
+	if err := checkOutput(cfg.Output); err != nil {
+		return err
+	}
+
+	jiras, err := cfg.client().Jiras(ctx, fs.Arg(0), fs.Arg(1), client.DiffOptions{})
+	if err != nil {
+		return err
+	}
+	return printJiras(cfg.Output, jiras)
+}
+
+func runVersions(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("versions", "<platform>", "Lists a platform's release versions.")
+	cfg.bind(fs, true)
+	status := fs.String("status", "", "comma-separated statuses to include")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected <platform>, got %d arguments", fs.NArg())
+	}
+	if err := checkOutput(cfg.Output); err != nil {
+		return err
+	}
+
+	vq := client.VersionQuery{}
+	if *status != "" {
+		vq.Statuses = strings.Split(*status, ",")
+	}
+	c := cfg.client()
+	var versions []client.VersionInfo
+	for {
+		page, err := c.Versions(ctx, fs.Arg(0), vq)
+		if err != nil {
+			return err
+		}
+		versions = append(versions, page.Versions...)
+		if page.NextCursor == "" {
+			break
+		}
+		vq.Cursor = page.NextCursor
+	}
+	return printVersions(cfg.Output, versions)
+}
+
+func runTree(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("tree", "<platform>", "Draws a platform's release tree with the number of jiras each release adds.")
+	cfg.bind(fs, true)
+	root := fs.String("root", "", "draw only the subtree under this version")
+	depth := fs.Int("depth", 0, "levels to draw; 0 means all")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected <platform>, got %d arguments", fs.NArg())
+	}
+	if err := checkOutput(cfg.Output); err != nil {
+		return err
+	}
+
+	info, err := cfg.client().Tree(ctx, fs.Arg(0), client.TreeQuery{Root: *root, Depth: *depth})
+	if err != nil {
+		return err
+	}
+	if cfg.Output == outputJSON {
+		return printJSON(os.Stdout, info)
+	}
+	printTree(os.Stdout, info)
+	return nil
+}
+
+func runDelete(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("delete", "<version>", "Deletes a release. It can be restored until the purge job removes it.")
+	cfg.bind(fs, false)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected <version>, got %d arguments", fs.NArg())
+	}
+
+	if err := cfg.client().DeleteRelease(ctx, fs.Arg(0), client.WriteOptions{}); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "deleted %s\n", fs.Arg(0))
+	return nil
+}
+
+func runExport(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("export", "releases|versions|jiras",
+		"Streams a whole list as CSV, NDJSON or JSON. releases and versions need -platform;\njiras needs -from and -to.")
+	cfg.bind(fs, false)
+	format := fs.String("format", client.FormatCSV, "csv, ndjson or json")
+	platform := fs.String("platform", "", "platform of the releases or versions")
+	from := fs.String("from", "", "version the jira diff starts after")
+	to := fs.String("to", "", "version the jira diff ends at")
+	out := fs.String("out", "-", "file to write; - is standard output")
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("expected what to export")
+	}
+	what := args[0]
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	c := cfg.client()
+	var body io.ReadCloser
+	var err error
+	switch what {
+	case "releases", "versions":
+		if *platform == "" {
+			return fmt.Errorf("-platform is required")
+		}
+		if what == "releases" {
+			body, err = c.ExportReleases(ctx, *platform, *format)
+		} else {
+			body, err = c.ExportVersions(ctx, *platform, client.VersionQuery{}, *format)
+		}
+	case "jiras":
+		if *from == "" || *to == "" {
+			return fmt.Errorf("-from and -to are required")
+		}
+		body, err = c.ExportJiras(ctx, *from, *to, client.DiffOptions{}, *format)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown export %q", what)
+	}
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	w := io.Writer(os.Stdout)
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("export %s: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+	"jiraiya/pkg/client"
+)
+
+// config holds the connection settings shared by every command. Values come
+// from the config file, then the environment, then command-line flags, each
+// overriding the last.
+type config struct {
+	Server string `yaml:"server"`
+	Token  string `yaml:"token"`
+	Output string `yaml:"output"`
+}
+
+// configPath is the config file read when JIRAIYA_CONFIG is unset.
+func configPath() string {
+	if p := os.Getenv("JIRAIYA_CONFIG"); p != "" {
+		return p
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "jiraiya", "config.yaml")
+}
+
+// loadConfig reads the config file, if any, and applies the environment. A
+// missing default config file is not an error.
+func loadConfig() (config, error) {
+	cfg := config{Server: "http://localhost:8080", Output: outputTable}
+	if path := configPath(); path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && os.Getenv("JIRAIYA_CONFIG") == "":
+		case err != nil:
+			return cfg, fmt.Errorf("read config: %w", err)
+		default:
+			if err := yaml.Unmarshal(data, &cfg); err != nil {
+				return cfg, fmt.Errorf("parse config %s: %w", path, err)
+			}
+		}
+	}
+	cfg.Server = envOr("JIRAIYA_URL", cfg.Server)
+	cfg.Token = envOr("JIRAIYA_TOKEN", cfg.Token)
+	cfg.Output = envOr("JIRAIYA_OUTPUT", cfg.Output)
+	return cfg, nil
+}
+
+// bind registers the connection flags on fs, defaulting to cfg. Commands that
+// print results also get -o.
+func (cfg *config) bind(fs *flag.FlagSet, output bool) {
+	fs.StringVar(&cfg.Server, "server", cfg.Server, "jiraiya server URL (env JIRAIYA_URL)")
+	fs.Var((*secret)(&cfg.Token), "token", "API `token` (env JIRAIYA_TOKEN)")
+	if output {
+		fs.StringVar(&cfg.Output, "o", cfg.Output, "output format: table or json (env JIRAIYA_OUTPUT)")
+	}
+}
+
+// secret is a string flag whose value is never printed in usage output.
+type secret string
+
+func (s *secret) String() string     { return "" }
+func (s *secret) Set(v string) error { *s = secret(v); return nil }
+
+func (cfg config) client() *client.Client {
+	return client.New(cfg.Server, client.WithToken(cfg.Token))
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
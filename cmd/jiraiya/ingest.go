@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"jiraiya/internal/ingest"
+	"jiraiya/pkg/client"
+)
+
+func runIngest(ctx context.Context, cfg config, args []string) error {
+	if len(args) == 0 || args[0] != "git" {
+		return fmt.Errorf("usage: jiraiya ingest git [flags] <prev-tag> <new-tag>")
+	}
+	return runIngestGit(ctx, cfg, args[1:])
+}
+
+func runIngestGit(ctx context.Context, cfg config, args []string) error {
+	fs := flag.NewFlagSet("ingest git", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: jiraiya ingest git [flags] <prev-tag> <new-tag>")
+		fmt.Fprintln(fs.Output(), "\nSubmits the jira keys found in commits after prev-tag up to new-tag.")
+		fmt.Fprintln(fs.Output(), "Pass an empty prev-tag for a platform's first release.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	repo := fs.String("repo", ".", "path of the local git repository, bare or not")
+	platform := fs.String("platform", "", "release platform (required)")
+	pattern := fs.String("pattern", ingest.DefaultJiraPattern, "regular expression matching jira keys in commit messages")
+	prefix := fs.String("tag-prefix", "", "prefix stripped from tags to form versions, e.g. v")
+	version := fs.String("version", "", "release version (default: new-tag without -tag-prefix)")
+	fromVer := fs.String("from-ver", "", "parent version (default: prev-tag without -tag-prefix)")
+	releaseDate := fs.String("release-date", "", "release date, YYYY-MM-DD")
+	cfg.bind(fs, false)
+	dryRun := fs.Bool("dry-run", false, "print the submission instead of sending it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("expected <prev-tag> and <new-tag>, got %d arguments", fs.NArg())
+	}
+	if *platform == "" {
+		return fmt.Errorf("-platform is required")
+	}
+	re, err := regexp.Compile(*pattern)
+	if err != nil {
+		return fmt.Errorf("invalid -pattern: %w", err)
+	}
+	prevTag, newTag := fs.Arg(0), fs.Arg(1)
+
+	ids, err := ingest.GitJiraIDs(ctx, ingest.GitRange{Repo: *repo, From: prevTag, To: newTag}, re)
+	if err != nil {
+		return err
+	}
+
+	sub := client.ReleaseSubmission{
+		Release: client.ReleaseInfo{
+			Version:     *version,
+			FromVer:     *fromVer,
+			Platform:    *platform,
+			ReleaseDate: *releaseDate,
+		},
+		Changes: make([]client.JiraInput, len(ids)),
+	}
+	if sub.Release.Version == "" {
+		sub.Release.Version = strings.TrimPrefix(newTag, *prefix)
+	}
+	if sub.Release.FromVer == "" && prevTag != "" {
+		sub.Release.FromVer = strings.TrimPrefix(prevTag, *prefix)
+	}
+	for i, id := range ids {
+		sub.Changes[i] = client.JiraInput{ID: id}
+	}
+
+	if *dryRun {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sub)
+	}
+	if _, err := cfg.client().SubmitRelease(ctx, sub, client.WriteOptions{}); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "submitted %s %s (from %q) with %d jiras\n",
+		sub.Release.Platform, sub.Release.Version, sub.Release.FromVer, len(ids))
+	return nil
+}
@@ -0,0 +1,86 @@
+// Command jiraiya is the command-line client for the jiraiya release API.
+//
+// The server URL and API token come from flags, the JIRAIYA_URL and
+// JIRAIYA_TOKEN environment variables, or the YAML config file at
+// JIRAIYA_CONFIG (default $XDG_CONFIG_HOME/jiraiya/config.yaml), in that
+// order of precedence. Writes are attributed to the token's name.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+)
+
+const usage = `usage: jiraiya <command> [flags]
+
+commands:
+  submit       submit a release from a YAML or JSON file
+  diff         list the jiras between two versions
+  versions     list a platform's versions
+  tree         draw a platform's release tree
+  delete       delete a release
+  export       stream releases, versions or jiras as CSV, NDJSON or JSON
+  ingest git   submit a release built from the jira keys in git history
+  token        create, list or revoke API tokens (admin)
+
+Run jiraiya <command> -h for a command's flags.
+`
+
+func main() {
+	godotenv.Load()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintln(os.Stderr, "jiraiya:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("no command given")
+	}
+	if cmd := args[0]; cmd == "help" || cmd == "-h" || cmd == "-help" || cmd == "--help" {
+		fmt.Fprint(os.Stdout, usage)
+		return nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	switch args[0] {
+	case "submit":
+		return runSubmit(ctx, cfg, args[1:])
+	case "diff":
+		return runDiff(ctx, cfg, args[1:])
+	case "versions":
+		return runVersions(ctx, cfg, args[1:])
+	case "tree":
+		return runTree(ctx, cfg, args[1:])
+	case "delete":
+		return runDelete(ctx, cfg, args[1:])
+	case "export":
+		return runExport(ctx, cfg, args[1:])
+	case "ingest":
+		return runIngest(ctx, cfg, args[1:])
+	case "token":
+		return runToken(ctx, cfg, args[1:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
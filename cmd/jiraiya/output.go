@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"jiraiya/pkg/client"
+)
+
+// Output formats selected with -o.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func checkOutput(format string) error {
+	if format != outputTable && format != outputJSON {
+		return fmt.Errorf("-o must be table or json, got %q", format)
+	}
+	return nil
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printTable writes rows under header as aligned columns.
+func printTable(w io.Writer, header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func printJiras(format string, jiras []client.JiraOutput) error {
+	if format == outputJSON {
+		return printJSON(os.Stdout, jiras)
+	}
+	rows := make([][]string, len(jiras))
+	for i, j := range jiras {
+		rows[i] = []string{j.ID, j.Release, j.Impact, j.Domain, j.Title}
+	}
+	return printTable(os.Stdout, []string{"ID", "RELEASE", "IMPACT", "DOMAIN", "TITLE"}, rows)
+}
+
+func printVersions(format string, versions []client.VersionInfo) error {
+	if format == outputJSON {
+		return printJSON(os.Stdout, versions)
+	}
+	rows := make([][]string, len(versions))
+	for i, v := range versions {
+		rows[i] = []string{v.Version, v.FromVer, v.ReleaseDate, v.Status, v.SubmittedBy}
+	}
+	return printTable(os.Stdout, []string{"VERSION", "FROM", "DATE", "STATUS", "SUBMITTED BY"}, rows)
+}
+
+// printTree draws the tree as indented ASCII branches, each node followed by
+// the number of jiras it adds over its parent.
+func printTree(w io.Writer, info *client.TreeInfo) {
+	nodes := make(map[string]int, len(info.Nodes))
+	for i, n := range info.Nodes {
+		nodes[n.Version] = i
+	}
+	label := func(version string) string {
+		i, ok := nodes[version]
+		if !ok {
+			return version + " ..."
+		}
+		return fmt.Sprintf("%s (%d)", version, len(info.Nodes[i].Changes))
+	}
+
+	var walk func(version, prefix string)
+	walk = func(version, prefix string) {
+		i, ok := nodes[version]
+		if !ok {
+			return
+		}
+		children := append([]string(nil), info.Nodes[i].Children...)
+		sort.Strings(children)
+		for k, child := range children {
+			branch, indent := "├── ", "│   "
+			if k == len(children)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintln(w, prefix+branch+label(child))
+			walk(child, prefix+indent)
+		}
+	}
+
+	if info.Root == "" {
+		fmt.Fprintf(w, "%s: no releases\n", info.Platform)
+		return
+	}
+	fmt.Fprintln(w, label(info.Root))
+	walk(info.Root, "")
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"jiraiya/pkg/client"
+)
+
+func runToken(ctx context.Context, cfg config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: jiraiya token create|list|revoke [flags]")
+	}
+	switch args[0] {
+	case "create":
+		return runTokenCreate(ctx, cfg, args[1:])
+	case "list":
+		return runTokenList(ctx, cfg, args[1:])
+	case "revoke":
+		return runTokenRevoke(ctx, cfg, args[1:])
+	}
+	return fmt.Errorf("unknown token command %q; expected create, list or revoke", args[0])
+}
+
+func runTokenCreate(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("token create", "<name>",
+		"Creates an API token and prints it. The token cannot be shown again, so store it now.\nWrites made with it are attributed to <name>.")
+	cfg.bind(fs, false)
+	role := fs.String("role", "", "reader, submitter or admin on every platform (default reader unless -grant is given)")
+	var grants []client.Grant
+	fs.Func("grant", "`platform=role` on one platform; repeatable", func(v string) error {
+		platform, r, ok := strings.Cut(v, "=")
+		if !ok || platform == "" || r == "" {
+			return fmt.Errorf("expected platform=role, got %q", v)
+		}
+		grants = append(grants, client.Grant{Platform: platform, Role: r})
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected <name>, got %d arguments", fs.NArg())
+	}
+	if *role == "" && len(grants) == 0 {
+		*role = "reader"
+	}
+
+	tok, err := cfg.client().CreateToken(ctx, client.TokenInput{Name: fs.Arg(0), Role: *role, Grants: grants})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "created token %d for %s (%s)\n", tok.ID, tok.Name, formatGrants(tok.Grants))
+	fmt.Println(tok.Token)
+	return nil
+}
+
+func runTokenList(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("token list", "", "Lists the API tokens, including revoked ones.")
+	cfg.bind(fs, true)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkOutput(cfg.Output); err != nil {
+		return err
+	}
+
+	toks, err := cfg.client().Tokens(ctx)
+	if err != nil {
+		return err
+	}
+	if cfg.Output == outputJSON {
+		return printJSON(os.Stdout, toks)
+	}
+	rows := make([][]string, len(toks))
+	for i, t := range toks {
+		rows[i] = []string{strconv.FormatInt(t.ID, 10), t.Name, formatGrants(t.Grants), t.CreatedBy, formatTime(t.LastUsedAt), formatTime(t.RevokedAt)}
+	}
+	return printTable(os.Stdout, []string{"ID", "NAME", "GRANTS", "CREATED BY", "LAST USED", "REVOKED"}, rows)
+}
+
+func runTokenRevoke(ctx context.Context, cfg config, args []string) error {
+	fs := newFlagSet("token revoke", "<id>", "Revokes an API token. Requests using it fail from then on.")
+	cfg.bind(fs, false)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected <id>, got %d arguments", fs.NArg())
+	}
+	id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+	if err != nil || id <= 0 {
+		return fmt.Errorf("id must be a positive integer, got %q", fs.Arg(0))
+	}
+
+	if err := cfg.client().RevokeToken(ctx, id); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "revoked token %d\n", id)
+	return nil
+}
+
+// formatGrants renders a token's grants as platform=role pairs.
+func formatGrants(grants []client.Grant) string {
+	if len(grants) == 0 {
+		return "-"
+	}
+	parts := make([]string, len(grants))
+	for i, g := range grants {
+		parts[i] = g.Platform + "=" + g.Role
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatTime renders an optional timestamp for a table cell.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
+	"jiraiya/internal/db"
 	"jiraiya/sql/schema"
 )
 
@@ -43,10 +44,22 @@ func main() {
 		os.Exit(1)
 	}
 
-	if _, err := pool.Exec(ctx, schema.InitSQL); err != nil {
-		log.Error("migration failed", "error", err)
+	for i, m := range schema.Migrations {
+		if _, err := pool.Exec(ctx, m); err != nil {
+			log.Error("migration failed", "index", i, "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Report release dates that could not be converted to DATE.
+	rejects, err := db.New(pool).GetReleaseDateRejects(ctx)
+	if err != nil {
+		log.Error("failed to read release date rejects", "error", err)
 		os.Exit(1)
 	}
+	for _, r := range rejects {
+		log.Warn("unparsable release_date cleared", "version", r.Version, "raw_value", r.RawValue)
+	}
 
 	log.Info("migrations applied")
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 	"jiraiya/internal/app"
+	"jiraiya/internal/jiraapi"
 	"jiraiya/internal/logger"
 )
 
@@ -27,6 +30,119 @@ func main() {
 	if cfg.Addr == "" {
 		cfg.Addr = ":8080"
 	}
+	// The gRPC API is off unless GRPC_ADDR is set, so upgrading does not
+	// open a new port.
+	cfg.GRPCAddr = os.Getenv("GRPC_ADDR")
+	if v := os.Getenv("IDEMPOTENCY_RETENTION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid IDEMPOTENCY_RETENTION", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.Service.IdempotencyRetention = d
+	}
+	if v := os.Getenv("RELEASE_AUTO_LOCK_DAYS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			log.Error("invalid RELEASE_AUTO_LOCK_DAYS", "value", v)
+			os.Exit(1)
+		}
+		cfg.Service.AutoLockDays = n
+	}
+	if v := os.Getenv("DELETED_RELEASE_RETENTION"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid DELETED_RELEASE_RETENTION", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.Service.DeletedRetention = d
+	}
+	if v := os.Getenv("PURGE_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid PURGE_INTERVAL", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.PurgeInterval = d
+	}
+	if v := os.Getenv("WEBHOOK_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid WEBHOOK_INTERVAL", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.WebhookInterval = d
+	}
+	if v := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			log.Error("invalid WEBHOOK_MAX_ATTEMPTS", "value", v)
+			os.Exit(1)
+		}
+		cfg.Service.WebhookMaxAttempts = n
+	}
+	if v := os.Getenv("WEBHOOK_BACKOFF"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid WEBHOOK_BACKOFF", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.Service.WebhookBackoff = d
+	}
+	if v := os.Getenv("WEBHOOK_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid WEBHOOK_TIMEOUT", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.Service.WebhookTimeout = d
+	}
+
+	// Jira enrichment is off unless JIRA_URL is set. A JIRA_FIELD_* variable
+	// that is set but empty leaves that attribute unmapped.
+	cfg.Service.Jira = jiraapi.Config{
+		BaseURL: os.Getenv("JIRA_URL"),
+		User:    os.Getenv("JIRA_USER"),
+		Token:   os.Getenv("JIRA_TOKEN"),
+		Fields:  jiraapi.DefaultFieldMap(),
+	}
+	for env, field := range map[string]*string{
+		"JIRA_FIELD_TITLE":    &cfg.Service.Jira.Fields.Title,
+		"JIRA_FIELD_DOMAIN":   &cfg.Service.Jira.Fields.Domain,
+		"JIRA_FIELD_IMPACT":   &cfg.Service.Jira.Fields.Impact,
+		"JIRA_FIELD_RELNOTES": &cfg.Service.Jira.Fields.Relnotes,
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			*field = v
+		}
+	}
+	if v := os.Getenv("JIRA_RATE_LIMIT"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n < 0 {
+			log.Error("invalid JIRA_RATE_LIMIT", "value", v)
+			os.Exit(1)
+		}
+		cfg.Service.Jira.RequestsPerSecond = n
+	}
+	if v := os.Getenv("JIRA_CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid JIRA_CACHE_TTL", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.Service.EnrichmentTTL = d
+	}
+	if v := os.Getenv("ENRICH_INTERVAL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Error("invalid ENRICH_INTERVAL", "value", v, "error", err)
+			os.Exit(1)
+		}
+		cfg.EnrichInterval = d
+	}
+
+	// BOOTSTRAP_ADMIN_TOKEN lets a fresh deployment mint its first tokens.
+	cfg.BootstrapAdminToken = os.Getenv("BOOTSTRAP_ADMIN_TOKEN")
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
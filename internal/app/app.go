@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"jiraiya/internal/grpcapi"
 	"jiraiya/internal/handler"
 	"jiraiya/internal/service"
 )
@@ -17,8 +19,34 @@ import (
 type Config struct {
 	DatabaseURL string
 	Addr        string
+	// GRPCAddr is where the gRPC API listens. Empty disables it.
+	GRPCAddr string
+	Service  service.Config
+	// PurgeInterval is how often soft-deleted releases past their retention
+	// are removed. Zero uses defaultPurgeInterval.
+	PurgeInterval time.Duration
+	// WebhookInterval is how often due webhook deliveries are sent. Zero uses
+	// defaultWebhookInterval.
+	WebhookInterval time.Duration
+	// EnrichInterval is how often jiras with empty fields are looked up in
+	// Jira, on top of the lookup that follows each submission. Zero uses
+	// defaultEnrichInterval.
+	EnrichInterval time.Duration
+	// BootstrapAdminToken, if set, is stored as an admin API token at
+	// startup so a new deployment can create the rest. Revoking it sticks
+	// across restarts.
+	BootstrapAdminToken string
 }
 
+// defaultPurgeInterval is used when Config.PurgeInterval is zero.
+const defaultPurgeInterval = time.Hour
+
+// defaultWebhookInterval is used when Config.WebhookInterval is zero.
+const defaultWebhookInterval = 5 * time.Second
+
+// defaultEnrichInterval is used when Config.EnrichInterval is zero.
+const defaultEnrichInterval = 15 * time.Minute
+
 // App orchestrates the full server lifecycle.
 type App struct {
 	cfg Config
@@ -55,22 +83,54 @@ func (a *App) Run(ctx context.Context) error {
 		return fmt.Errorf("database not ready after 30s: %w", err)
 	}
 
-	svc := service.New(pool, a.log)
+	svc := service.New(pool, a.log, a.cfg.Service)
+	// Deferred after pool.Close, so background work ends before the pool
+	// does.
+	defer svc.Close()
 	if err := svc.LoadTrees(ctx); err != nil {
 		return fmt.Errorf("load trees: %w", err)
 	}
 	a.log.Info("trees loaded")
 
+	if a.cfg.BootstrapAdminToken != "" {
+		if err := svc.EnsureToken(ctx, "bootstrap", service.RoleAdmin, a.cfg.BootstrapAdminToken); err != nil {
+			return fmt.Errorf("bootstrap admin token: %w", err)
+		}
+	}
+
+	go a.purgeLoop(ctx, svc)
+	go a.webhookLoop(ctx, svc)
+	if a.cfg.Service.Jira.BaseURL != "" {
+		go a.enrichLoop(ctx, svc)
+	}
+
 	h := handler.New(svc, a.log)
 	srv := &http.Server{Addr: a.cfg.Addr, Handler: h.Routes()}
 
-	errCh := make(chan error, 1)
+	var grpcSrv *grpcapi.Server
+	var grpcLis net.Listener
+	if a.cfg.GRPCAddr != "" {
+		if grpcLis, err = net.Listen("tcp", a.cfg.GRPCAddr); err != nil {
+			return fmt.Errorf("grpc listen: %w", err)
+		}
+		grpcSrv = grpcapi.New(svc, a.log)
+	}
+
+	errCh := make(chan error, 2)
 	go func() {
 		a.log.Info("server starting", "addr", a.cfg.Addr)
 		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 			errCh <- err
 		}
 	}()
+	if grpcSrv != nil {
+		go func() {
+			a.log.Info("grpc server starting", "addr", a.cfg.GRPCAddr)
+			if err := grpcSrv.Serve(grpcLis); err != nil {
+				errCh <- fmt.Errorf("grpc: %w", err)
+			}
+		}()
+	}
 
 	select {
 	case err := <-errCh:
@@ -82,10 +142,90 @@ func (a *App) Run(ctx context.Context) error {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(shutdownCtx); err != nil {
+	// Both servers drain in-flight requests against the same deadline.
+	var grpcErr error
+	grpcDone := make(chan struct{})
+	go func() {
+		defer close(grpcDone)
+		if grpcSrv != nil {
+			grpcErr = grpcSrv.Shutdown(shutdownCtx)
+		}
+	}()
+	err = srv.Shutdown(shutdownCtx)
+	<-grpcDone
+	if err := errors.Join(err, grpcErr); err != nil {
 		return fmt.Errorf("shutdown: %w", err)
 	}
 
 	a.log.Info("server stopped")
 	return nil
 }
+
+// purgeLoop periodically hard-deletes soft-deleted releases and expired
+// idempotency keys until ctx is cancelled.
+func (a *App) purgeLoop(ctx context.Context, svc service.Service) {
+	interval := a.cfg.PurgeInterval
+	if interval <= 0 {
+		interval = defaultPurgeInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := svc.PurgeDeletedReleases(ctx); err != nil {
+				a.log.Error("purge deleted releases failed", "error", err)
+			}
+			if _, err := svc.PurgeIdempotencyKeys(ctx); err != nil {
+				a.log.Error("purge idempotency keys failed", "error", err)
+			}
+		}
+	}
+}
+
+// webhookLoop periodically sends due webhook deliveries until ctx is
+// cancelled.
+func (a *App) webhookLoop(ctx context.Context, svc service.Service) {
+	interval := a.cfg.WebhookInterval
+	if interval <= 0 {
+		interval = defaultWebhookInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := svc.DeliverWebhooks(ctx); err != nil {
+				a.log.Error("deliver webhooks failed", "error", err)
+			}
+		}
+	}
+}
+
+// enrichLoop periodically fills in empty jira metadata from Jira until ctx is
+// cancelled.
+func (a *App) enrichLoop(ctx context.Context, svc service.Service) {
+	interval := a.cfg.EnrichInterval
+	if interval <= 0 {
+		interval = defaultEnrichInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := svc.EnrichJiras(ctx); err != nil {
+				a.log.Error("enrich jiras failed", "error", err)
+			}
+		}
+	}
+}
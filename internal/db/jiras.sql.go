@@ -7,14 +7,37 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countJiras = `-- name: CountJiras :one
+SELECT count(*)
+FROM jiras
+WHERE ($1::text = '' OR to_tsvector('english', title || ' ' || relnotes) @@ websearch_to_tsquery('english', $1::text))
+  AND ($2::text = '' OR domain = $2::text)
+  AND ($3::text = '' OR impact = $3::text)
+`
+
+type CountJirasParams struct {
+	Query  string `json:"query"`
+	Domain string `json:"domain"`
+	Impact string `json:"impact"`
+}
+
+func (q *Queries) CountJiras(ctx context.Context, arg CountJirasParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countJiras, arg.Query, arg.Domain, arg.Impact)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getDistinctDomains = `-- name: GetDistinctDomains :many
 SELECT DISTINCT j.domain
 FROM jiras j
 JOIN release_jiras rj ON rj.jira_id = j.id
 JOIN releases r ON r.version = rj.release_version
-WHERE r.platform = $1 AND j.domain != ''
+WHERE r.platform = $1 AND r.deleted_at IS NULL AND j.domain != ''
 ORDER BY j.domain
 `
 
@@ -43,7 +66,7 @@ SELECT DISTINCT j.impact
 FROM jiras j
 JOIN release_jiras rj ON rj.jira_id = j.id
 JOIN releases r ON r.version = rj.release_version
-WHERE r.platform = $1 AND j.impact != ''
+WHERE r.platform = $1 AND r.deleted_at IS NULL AND j.impact != ''
 ORDER BY j.impact
 `
 
@@ -67,6 +90,25 @@ func (q *Queries) GetDistinctImpacts(ctx context.Context, platform string) ([]st
 	return items, nil
 }
 
+const getJira = `-- name: GetJira :one
+SELECT id, title, impact, domain, relnotes
+FROM jiras
+WHERE id = $1
+`
+
+func (q *Queries) GetJira(ctx context.Context, id string) (Jira, error) {
+	row := q.db.QueryRow(ctx, getJira, id)
+	var i Jira
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Impact,
+		&i.Domain,
+		&i.Relnotes,
+	)
+	return i, err
+}
+
 const getJirasByIDs = `-- name: GetJirasByIDs :many
 SELECT id, title, impact, domain, relnotes
 FROM jiras
@@ -99,14 +141,180 @@ func (q *Queries) GetJirasByIDs(ctx context.Context, ids []string) ([]Jira, erro
 	return items, nil
 }
 
+const getReleaseVersionsByJira = `-- name: GetReleaseVersionsByJira :many
+SELECT r.version
+FROM releases r
+JOIN release_jiras rj ON rj.release_version = r.version
+WHERE rj.jira_id = $1 AND r.deleted_at IS NULL
+  AND ($2::bool OR r.platform = ANY($3::text[]))
+ORDER BY r.version
+`
+
+type GetReleaseVersionsByJiraParams struct {
+	JiraID       string   `json:"jira_id"`
+	AllPlatforms bool     `json:"all_platforms"`
+	Platforms    []string `json:"platforms"`
+}
+
+// Unless all_platforms is set, only releases on platforms are returned.
+func (q *Queries) GetReleaseVersionsByJira(ctx context.Context, arg GetReleaseVersionsByJiraParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getReleaseVersionsByJira, arg.JiraID, arg.AllPlatforms, arg.Platforms)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		items = append(items, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReleaseVersionsByJiras = `-- name: GetReleaseVersionsByJiras :many
+SELECT rj.jira_id, r.version
+FROM releases r
+JOIN release_jiras rj ON rj.release_version = r.version
+WHERE rj.jira_id = ANY($1::text[]) AND r.deleted_at IS NULL
+  AND ($2::bool OR r.platform = ANY($3::text[]))
+ORDER BY rj.jira_id, r.version
+`
+
+type GetReleaseVersionsByJirasParams struct {
+	Ids          []string `json:"ids"`
+	AllPlatforms bool     `json:"all_platforms"`
+	Platforms    []string `json:"platforms"`
+}
+
+type GetReleaseVersionsByJirasRow struct {
+	JiraID  string `json:"jira_id"`
+	Version string `json:"version"`
+}
+
+// Unless all_platforms is set, only releases on platforms are returned.
+func (q *Queries) GetReleaseVersionsByJiras(ctx context.Context, arg GetReleaseVersionsByJirasParams) ([]GetReleaseVersionsByJirasRow, error) {
+	rows, err := q.db.Query(ctx, getReleaseVersionsByJiras, arg.Ids, arg.AllPlatforms, arg.Platforms)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReleaseVersionsByJirasRow
+	for rows.Next() {
+		var i GetReleaseVersionsByJirasRow
+		if err := rows.Scan(&i.JiraID, &i.Version); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchJiras = `-- name: SearchJiras :many
+SELECT id, title, impact, domain, relnotes
+FROM jiras
+WHERE ($1::text = '' OR to_tsvector('english', title || ' ' || relnotes) @@ websearch_to_tsquery('english', $1::text))
+  AND ($2::text = '' OR domain = $2::text)
+  AND ($3::text = '' OR impact = $3::text)
+ORDER BY ts_rank(to_tsvector('english', title || ' ' || relnotes), websearch_to_tsquery('english', $1::text)) DESC, id
+LIMIT $4::int OFFSET $5::int
+`
+
+type SearchJirasParams struct {
+	Query      string `json:"query"`
+	Domain     string `json:"domain"`
+	Impact     string `json:"impact"`
+	PageLimit  int32  `json:"page_limit"`
+	PageOffset int32  `json:"page_offset"`
+}
+
+// The text match must use the same expression as idx_jiras_fts for the
+// index to apply.
+func (q *Queries) SearchJiras(ctx context.Context, arg SearchJirasParams) ([]Jira, error) {
+	rows, err := q.db.Query(ctx, searchJiras,
+		arg.Query,
+		arg.Domain,
+		arg.Impact,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Jira
+	for rows.Next() {
+		var i Jira
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Impact,
+			&i.Domain,
+			&i.Relnotes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateJira = `-- name: UpdateJira :one
+UPDATE jiras SET
+    title = COALESCE($1, title),
+    impact = COALESCE($2, impact),
+    domain = COALESCE($3, domain),
+    relnotes = COALESCE($4, relnotes)
+WHERE id = $5
+RETURNING id, title, impact, domain, relnotes
+`
+
+type UpdateJiraParams struct {
+	Title    pgtype.Text `json:"title"`
+	Impact   pgtype.Text `json:"impact"`
+	Domain   pgtype.Text `json:"domain"`
+	Relnotes pgtype.Text `json:"relnotes"`
+	ID       string      `json:"id"`
+}
+
+func (q *Queries) UpdateJira(ctx context.Context, arg UpdateJiraParams) (Jira, error) {
+	row := q.db.QueryRow(ctx, updateJira,
+		arg.Title,
+		arg.Impact,
+		arg.Domain,
+		arg.Relnotes,
+		arg.ID,
+	)
+	var i Jira
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Impact,
+		&i.Domain,
+		&i.Relnotes,
+	)
+	return i, err
+}
+
 const upsertJira = `-- name: UpsertJira :exec
 INSERT INTO jiras (id, title, impact, domain, relnotes)
 VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (id) DO UPDATE SET
-    title = EXCLUDED.title,
-    impact = EXCLUDED.impact,
-    domain = EXCLUDED.domain,
-    relnotes = EXCLUDED.relnotes
+    title = COALESCE(NULLIF(EXCLUDED.title, ''), jiras.title),
+    impact = COALESCE(NULLIF(EXCLUDED.impact, ''), jiras.impact),
+    domain = COALESCE(NULLIF(EXCLUDED.domain, ''), jiras.domain),
+    relnotes = COALESCE(NULLIF(EXCLUDED.relnotes, ''), jiras.relnotes)
 `
 
 type UpsertJiraParams struct {
@@ -117,6 +325,8 @@ type UpsertJiraParams struct {
 	Relnotes string `json:"relnotes"`
 }
 
+// Empty submitted fields keep the stored value: CI usually submits bare ids,
+// and those must not wipe metadata set earlier or filled in from Jira.
 func (q *Queries) UpsertJira(ctx context.Context, arg UpsertJiraParams) error {
 	_, err := q.db.Exec(ctx, upsertJira,
 		arg.ID,
@@ -8,6 +8,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiToken struct {
+	ID         int64              `json:"id"`
+	Name       string             `json:"name"`
+	Role       string             `json:"role"`
+	TokenHash  []byte             `json:"token_hash"`
+	CreatedBy  string             `json:"created_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type ApiTokenGrant struct {
+	TokenID  int64  `json:"token_id"`
+	Platform string `json:"platform"`
+	Role     string `json:"role"`
+}
+
+type IdempotencyKey struct {
+	Key         string             `json:"key"`
+	RequestHash string             `json:"request_hash"`
+	Response    []byte             `json:"response"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
 type Jira struct {
 	ID       string `json:"id"`
 	Title    string `json:"title"`
@@ -16,17 +40,92 @@ type Jira struct {
 	Relnotes string `json:"relnotes"`
 }
 
+type JiraEnrichment struct {
+	JiraID    string             `json:"jira_id"`
+	Title     string             `json:"title"`
+	Impact    string             `json:"impact"`
+	Domain    string             `json:"domain"`
+	Relnotes  string             `json:"relnotes"`
+	Found     bool               `json:"found"`
+	FetchedAt pgtype.Timestamptz `json:"fetched_at"`
+	FailedAt  pgtype.Timestamptz `json:"failed_at"`
+}
+
+type JiraRevision struct {
+	ID             int64              `json:"id"`
+	JiraID         string             `json:"jira_id"`
+	ReleaseVersion string             `json:"release_version"`
+	Title          string             `json:"title"`
+	Impact         string             `json:"impact"`
+	Domain         string             `json:"domain"`
+	Relnotes       string             `json:"relnotes"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
 type Release struct {
 	Version     string             `json:"version"`
 	FromVer     string             `json:"from_ver"`
 	Platform    string             `json:"platform"`
-	ReleaseDate string             `json:"release_date"`
+	ReleaseDate pgtype.Date        `json:"release_date"`
 	SubmittedBy string             `json:"submitted_by"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Status      string             `json:"status"`
+	Locked      bool               `json:"locked"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type ReleaseAudit struct {
+	ID             int64              `json:"id"`
+	ReleaseVersion string             `json:"release_version"`
+	Platform       string             `json:"platform"`
+	Action         string             `json:"action"`
+	Actor          string             `json:"actor"`
+	Payload        []byte             `json:"payload"`
+	AddedJiras     []string           `json:"added_jiras"`
+	RemovedJiras   []string           `json:"removed_jiras"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type ReleaseDateReject struct {
+	Version    string             `json:"version"`
+	RawValue   string             `json:"raw_value"`
+	RecordedAt pgtype.Timestamptz `json:"recorded_at"`
 }
 
 type ReleaseJira struct {
 	ReleaseVersion string `json:"release_version"`
 	JiraID         string `json:"jira_id"`
 }
+
+type WebhookDelivery struct {
+	ID             int64              `json:"id"`
+	EventID        int64              `json:"event_id"`
+	SubscriptionID int64              `json:"subscription_id"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastStatusCode int32              `json:"last_status_code"`
+	LastError      string             `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WebhookEvent struct {
+	ID             int64              `json:"id"`
+	Platform       string             `json:"platform"`
+	EventType      string             `json:"event_type"`
+	ReleaseVersion string             `json:"release_version"`
+	Payload        []byte             `json:"payload"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type WebhookSubscription struct {
+	ID        int64              `json:"id"`
+	Platform  string             `json:"platform"`
+	Url       string             `json:"url"`
+	Secret    string             `json:"secret"`
+	Events    []string           `json:"events"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
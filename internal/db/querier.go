@@ -6,22 +6,127 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
-	DeleteRelease(ctx context.Context, version string) error
+	// Leases due deliveries by pushing next_attempt_at out to lease_until, so a
+	// worker that dies mid-delivery only delays the retry.
+	ClaimWebhookDeliveries(ctx context.Context, arg ClaimWebhookDeliveriesParams) ([]ClaimWebhookDeliveriesRow, error)
+	CountJiras(ctx context.Context, arg CountJirasParams) (int64, error)
+	CountReleasesByPlatform(ctx context.Context, platform string) (int64, error)
+	CountVersionsByPlatform(ctx context.Context, arg CountVersionsByPlatformParams) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteWebhookSubscription(ctx context.Context, id int64) (int64, error)
+	// A revoked token stays revoked even if it is ensured again.
+	EnsureAPIToken(ctx context.Context, arg EnsureAPITokenParams) error
+	// Gives an ensured token its role on every platform unless it already has
+	// grants.
+	EnsureAPITokenGrant(ctx context.Context, tokenHash []byte) error
+	// Copies cached values into the jira's empty fields only, so values set by a
+	// submission or PATCH always win. Returns no row if nothing was filled.
+	FillJiraFromEnrichment(ctx context.Context, id string) (Jira, error)
+	GetAPITokenByHash(ctx context.Context, tokenHash []byte) (ApiToken, error)
+	GetAPITokenGrants(ctx context.Context, tokenID int64) ([]ApiTokenGrant, error)
 	GetAllPlatforms(ctx context.Context) ([]string, error)
 	GetAllReleasesByPlatform(ctx context.Context, platform string) ([]Release, error)
 	GetDistinctDomains(ctx context.Context, platform string) ([]string, error)
 	GetDistinctImpacts(ctx context.Context, platform string) ([]string, error)
+	// Expired keys are ignored until the purge job removes them.
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetJira(ctx context.Context, id string) (Jira, error)
 	GetJiraIDsByRelease(ctx context.Context, releaseVersion string) ([]string, error)
+	GetJiraRevisionsAsOf(ctx context.Context, arg GetJiraRevisionsAsOfParams) ([]GetJiraRevisionsAsOfRow, error)
 	GetJirasByIDs(ctx context.Context, ids []string) ([]Jira, error)
+	GetLatestWebhookEventID(ctx context.Context) (int64, error)
 	GetRelease(ctx context.Context, version string) (Release, error)
-	GetVersionsByPlatform(ctx context.Context, platform string) ([]GetVersionsByPlatformRow, error)
+	GetReleaseAudit(ctx context.Context, releaseVersion string) ([]ReleaseAudit, error)
+	GetReleaseDateRejects(ctx context.Context) ([]ReleaseDateReject, error)
+	GetReleaseStatusesByPlatform(ctx context.Context, platform string) ([]GetReleaseStatusesByPlatformRow, error)
+	// Unless all_platforms is set, only releases on platforms are returned.
+	GetReleaseVersionsByJira(ctx context.Context, arg GetReleaseVersionsByJiraParams) ([]string, error)
+	// Unless all_platforms is set, only releases on platforms are returned.
+	GetReleaseVersionsByJiras(ctx context.Context, arg GetReleaseVersionsByJirasParams) ([]GetReleaseVersionsByJirasRow, error)
+	GetReleasesByVersions(ctx context.Context, versions []string) ([]Release, error)
+	GetWebhookDeliveryPlatform(ctx context.Context, id int64) (string, error)
+	GetWebhookSubscription(ctx context.Context, id int64) (WebhookSubscription, error)
+	InsertAPIToken(ctx context.Context, arg InsertAPITokenParams) (ApiToken, error)
+	InsertAPITokenGrant(ctx context.Context, arg InsertAPITokenGrantParams) error
+	// An expired key that has not been purged yet is taken over.
+	InsertIdempotencyKey(ctx context.Context, arg InsertIdempotencyKeyParams) (int64, error)
+	InsertJiraRevision(ctx context.Context, arg InsertJiraRevisionParams) error
+	InsertReleaseAudit(ctx context.Context, arg InsertReleaseAuditParams) error
+	InsertWebhookEvent(ctx context.Context, arg InsertWebhookEventParams) (int64, error)
+	InsertWebhookSubscription(ctx context.Context, arg InsertWebhookSubscriptionParams) (WebhookSubscription, error)
 	LinkJiraToRelease(ctx context.Context, arg LinkJiraToReleaseParams) error
+	ListAPITokenGrants(ctx context.Context) ([]ApiTokenGrant, error)
+	ListAPITokens(ctx context.Context) ([]ApiToken, error)
+	// Jiras missing a wanted field whose cached lookup is absent or older than
+	// stale_before, or whose last lookup failed before retry_before.
+	ListJirasToEnrich(ctx context.Context, arg ListJirasToEnrichParams) ([]string, error)
+	// Like ListReleasesByVersion, by creation time with version breaking ties.
+	ListReleasesByCreatedAt(ctx context.Context, arg ListReleasesByCreatedAtParams) ([]Release, error)
+	// Like ListReleasesByVersion, by release date with version breaking ties.
+	// Undated releases come last in either order: each branch sorts a NULL date
+	// past the end it reads towards, using the expression its
+	// idx_releases_platform_date_* index is built on.
+	ListReleasesByReleaseDate(ctx context.Context, arg ListReleasesByReleaseDateParams) ([]Release, error)
+	// Like ListReleasesByVersion, by last update with version breaking ties.
+	ListReleasesByUpdatedAt(ctx context.Context, arg ListReleasesByUpdatedAtParams) ([]Release, error)
+	// Keyset page over a platform's live releases by version. since, until and
+	// statuses narrow the versions list; the releases list leaves them empty.
+	// Each order has its own branch so it reads idx_releases_platform_version in
+	// index order; only the requested one returns rows.
+	ListReleasesByVersion(ctx context.Context, arg ListReleasesByVersionParams) ([]Release, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]ListWebhookDeliveriesRow, error)
+	// Reads the outbox in id order after after_id, for clients that follow
+	// release events as a stream. Unless all_platforms is set, only events on
+	// platforms are returned.
+	ListWebhookEvents(ctx context.Context, arg ListWebhookEventsParams) ([]WebhookEvent, error)
+	ListWebhookSubscriptions(ctx context.Context, arg ListWebhookSubscriptionsParams) ([]WebhookSubscription, error)
+	// Unlike GetRelease this also returns soft-deleted rows, so restores and
+	// re-submissions can lock them.
+	LockRelease(ctx context.Context, version string) (Release, error)
+	// Held shared by every transaction that inserts an event, from before its id
+	// is drawn until it ends. Inserts do not wait for each other; see
+	// WaitWebhookEvents.
+	LockWebhookEvents(ctx context.Context) error
+	// Records a failed lookup. A cached earlier result is kept as it was.
+	MarkJiraEnrichmentFailed(ctx context.Context, jiraID string) error
+	PurgeDeletedReleases(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]PurgeDeletedReleasesRow, error)
+	// Fans an outbox event out to every subscription on its platform that listens
+	// for the event type. An empty events list listens for everything.
+	QueueWebhookDeliveries(ctx context.Context, id int64) (int64, error)
+	RecordWebhookAttempt(ctx context.Context, arg RecordWebhookAttemptParams) error
+	RestoreRelease(ctx context.Context, version string) error
+	RetryWebhookDelivery(ctx context.Context, id int64) (int64, error)
+	RevokeAPIToken(ctx context.Context, id int64) (int64, error)
+	// The text match must use the same expression as idx_jiras_fts for the
+	// index to apply.
+	SearchJiras(ctx context.Context, arg SearchJirasParams) ([]Jira, error)
+	SetReleaseLocked(ctx context.Context, arg SetReleaseLockedParams) error
+	SoftDeleteRelease(ctx context.Context, version string) error
+	// Records use at most once a minute so authentication stays read-mostly.
+	TouchAPIToken(ctx context.Context, id int64) error
+	TouchRelease(ctx context.Context, version string) error
+	UnlinkJiraFromRelease(ctx context.Context, arg UnlinkJiraFromReleaseParams) (int64, error)
 	UnlinkJirasFromRelease(ctx context.Context, releaseVersion string) error
+	UpdateJira(ctx context.Context, arg UpdateJiraParams) (Jira, error)
+	UpdateReleaseMetadata(ctx context.Context, arg UpdateReleaseMetadataParams) error
+	UpdateReleaseStatus(ctx context.Context, arg UpdateReleaseStatusParams) error
+	// Empty submitted fields keep the stored value: CI usually submits bare ids,
+	// and those must not wipe metadata set earlier or filled in from Jira.
 	UpsertJira(ctx context.Context, arg UpsertJiraParams) error
+	UpsertJiraEnrichment(ctx context.Context, arg UpsertJiraEnrichmentParams) error
+	// A submission for a soft-deleted version recreates it: the old status and
+	// lock are discarded along with the deletion marker.
 	UpsertRelease(ctx context.Context, arg UpsertReleaseParams) error
+	// Waits out every transaction that has drawn an event id but not yet ended,
+	// and holds off new ones until the caller's transaction ends. Readers
+	// following the log by id take it before reading, so an id below one they
+	// have seen can never commit late.
+	WaitWebhookEvents(ctx context.Context) error
 }
 
 var _ Querier = (*Queries)(nil)
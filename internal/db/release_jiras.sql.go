@@ -49,6 +49,23 @@ func (q *Queries) LinkJiraToRelease(ctx context.Context, arg LinkJiraToReleasePa
 	return err
 }
 
+const unlinkJiraFromRelease = `-- name: UnlinkJiraFromRelease :execrows
+DELETE FROM release_jiras WHERE release_version = $1 AND jira_id = $2
+`
+
+type UnlinkJiraFromReleaseParams struct {
+	ReleaseVersion string `json:"release_version"`
+	JiraID         string `json:"jira_id"`
+}
+
+func (q *Queries) UnlinkJiraFromRelease(ctx context.Context, arg UnlinkJiraFromReleaseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, unlinkJiraFromRelease, arg.ReleaseVersion, arg.JiraID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const unlinkJirasFromRelease = `-- name: UnlinkJirasFromRelease :exec
 DELETE FROM release_jiras WHERE release_version = $1
 `
@@ -11,17 +11,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const deleteRelease = `-- name: DeleteRelease :exec
-DELETE FROM releases WHERE version = $1
+const countReleasesByPlatform = `-- name: CountReleasesByPlatform :one
+SELECT count(*) FROM releases WHERE platform = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteRelease(ctx context.Context, version string) error {
-	_, err := q.db.Exec(ctx, deleteRelease, version)
-	return err
+func (q *Queries) CountReleasesByPlatform(ctx context.Context, platform string) (int64, error) {
+	row := q.db.QueryRow(ctx, countReleasesByPlatform, platform)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countVersionsByPlatform = `-- name: CountVersionsByPlatform :one
+SELECT count(*)
+FROM releases
+WHERE platform = $1
+  AND deleted_at IS NULL
+  AND ($2::date IS NULL OR release_date >= $2::date)
+  AND ($3::date IS NULL OR release_date <= $3::date)
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+`
+
+type CountVersionsByPlatformParams struct {
+	Platform string      `json:"platform"`
+	Since    pgtype.Date `json:"since"`
+	Until    pgtype.Date `json:"until"`
+	Statuses []string    `json:"statuses"`
+}
+
+func (q *Queries) CountVersionsByPlatform(ctx context.Context, arg CountVersionsByPlatformParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countVersionsByPlatform,
+		arg.Platform,
+		arg.Since,
+		arg.Until,
+		arg.Statuses,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
 }
 
 const getAllPlatforms = `-- name: GetAllPlatforms :many
-SELECT DISTINCT platform FROM releases WHERE platform != '' ORDER BY platform
+SELECT DISTINCT platform FROM releases WHERE platform != '' AND deleted_at IS NULL ORDER BY platform
 `
 
 func (q *Queries) GetAllPlatforms(ctx context.Context) ([]string, error) {
@@ -45,9 +76,9 @@ func (q *Queries) GetAllPlatforms(ctx context.Context) ([]string, error) {
 }
 
 const getAllReleasesByPlatform = `-- name: GetAllReleasesByPlatform :many
-SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
 FROM releases
-WHERE platform = $1
+WHERE platform = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetAllReleasesByPlatform(ctx context.Context, platform string) ([]Release, error) {
@@ -67,6 +98,9 @@ func (q *Queries) GetAllReleasesByPlatform(ctx context.Context, platform string)
 			&i.SubmittedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.Locked,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -79,9 +113,9 @@ func (q *Queries) GetAllReleasesByPlatform(ctx context.Context, platform string)
 }
 
 const getRelease = `-- name: GetRelease :one
-SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
 FROM releases
-WHERE version = $1
+WHERE version = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetRelease(ctx context.Context, version string) (Release, error) {
@@ -95,42 +129,402 @@ func (q *Queries) GetRelease(ctx context.Context, version string) (Release, erro
 		&i.SubmittedBy,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.Locked,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const getVersionsByPlatform = `-- name: GetVersionsByPlatform :many
-SELECT version, from_ver, release_date, submitted_by, created_at, updated_at
+const getReleaseDateRejects = `-- name: GetReleaseDateRejects :many
+SELECT version, raw_value, recorded_at
+FROM release_date_rejects
+ORDER BY version
+`
+
+func (q *Queries) GetReleaseDateRejects(ctx context.Context) ([]ReleaseDateReject, error) {
+	rows, err := q.db.Query(ctx, getReleaseDateRejects)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReleaseDateReject
+	for rows.Next() {
+		var i ReleaseDateReject
+		if err := rows.Scan(&i.Version, &i.RawValue, &i.RecordedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReleaseStatusesByPlatform = `-- name: GetReleaseStatusesByPlatform :many
+SELECT version, status FROM releases WHERE platform = $1 AND deleted_at IS NULL
+`
+
+type GetReleaseStatusesByPlatformRow struct {
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+func (q *Queries) GetReleaseStatusesByPlatform(ctx context.Context, platform string) ([]GetReleaseStatusesByPlatformRow, error) {
+	rows, err := q.db.Query(ctx, getReleaseStatusesByPlatform, platform)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReleaseStatusesByPlatformRow
+	for rows.Next() {
+		var i GetReleaseStatusesByPlatformRow
+		if err := rows.Scan(&i.Version, &i.Status); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReleasesByVersions = `-- name: GetReleasesByVersions :many
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
 FROM releases
-WHERE platform = $1
-ORDER BY release_date DESC
+WHERE version = ANY($1::text[]) AND deleted_at IS NULL
+`
+
+func (q *Queries) GetReleasesByVersions(ctx context.Context, versions []string) ([]Release, error) {
+	rows, err := q.db.Query(ctx, getReleasesByVersions, versions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Release
+	for rows.Next() {
+		var i Release
+		if err := rows.Scan(
+			&i.Version,
+			&i.FromVer,
+			&i.Platform,
+			&i.ReleaseDate,
+			&i.SubmittedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Locked,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReleasesByCreatedAt = `-- name: ListReleasesByCreatedAt :many
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR (created_at, version) > ($7::timestamptz, $6::text))
+ORDER BY created_at, version
+LIMIT $8::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR (created_at, version) < ($7::timestamptz, $6::text))
+ORDER BY created_at DESC, version DESC
+LIMIT $8::int)
+`
+
+type ListReleasesByCreatedAtParams struct {
+	Descending   bool               `json:"descending"`
+	Platform     string             `json:"platform"`
+	Since        pgtype.Date        `json:"since"`
+	Until        pgtype.Date        `json:"until"`
+	Statuses     []string           `json:"statuses"`
+	AfterVersion string             `json:"after_version"`
+	AfterTime    pgtype.Timestamptz `json:"after_time"`
+	PageLimit    int32              `json:"page_limit"`
+}
+
+// Like ListReleasesByVersion, by creation time with version breaking ties.
+func (q *Queries) ListReleasesByCreatedAt(ctx context.Context, arg ListReleasesByCreatedAtParams) ([]Release, error) {
+	rows, err := q.db.Query(ctx, listReleasesByCreatedAt,
+		arg.Descending,
+		arg.Platform,
+		arg.Since,
+		arg.Until,
+		arg.Statuses,
+		arg.AfterVersion,
+		arg.AfterTime,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Release
+	for rows.Next() {
+		var i Release
+		if err := rows.Scan(
+			&i.Version,
+			&i.FromVer,
+			&i.Platform,
+			&i.ReleaseDate,
+			&i.SubmittedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Locked,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReleasesByReleaseDate = `-- name: ListReleasesByReleaseDate :many
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR (COALESCE(release_date, 'infinity'::date), version) > (COALESCE($7::date, 'infinity'::date), $6::text))
+ORDER BY COALESCE(release_date, 'infinity'::date), version
+LIMIT $8::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR (COALESCE(release_date, '-infinity'::date), version) < (COALESCE($7::date, '-infinity'::date), $6::text))
+ORDER BY COALESCE(release_date, '-infinity'::date) DESC, version DESC
+LIMIT $8::int)
+`
+
+type ListReleasesByReleaseDateParams struct {
+	Descending   bool        `json:"descending"`
+	Platform     string      `json:"platform"`
+	Since        pgtype.Date `json:"since"`
+	Until        pgtype.Date `json:"until"`
+	Statuses     []string    `json:"statuses"`
+	AfterVersion string      `json:"after_version"`
+	AfterDate    pgtype.Date `json:"after_date"`
+	PageLimit    int32       `json:"page_limit"`
+}
+
+// Like ListReleasesByVersion, by release date with version breaking ties.
+// Undated releases come last in either order: each branch sorts a NULL date
+// past the end it reads towards, using the expression its
+// idx_releases_platform_date_* index is built on.
+func (q *Queries) ListReleasesByReleaseDate(ctx context.Context, arg ListReleasesByReleaseDateParams) ([]Release, error) {
+	rows, err := q.db.Query(ctx, listReleasesByReleaseDate,
+		arg.Descending,
+		arg.Platform,
+		arg.Since,
+		arg.Until,
+		arg.Statuses,
+		arg.AfterVersion,
+		arg.AfterDate,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Release
+	for rows.Next() {
+		var i Release
+		if err := rows.Scan(
+			&i.Version,
+			&i.FromVer,
+			&i.Platform,
+			&i.ReleaseDate,
+			&i.SubmittedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Locked,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReleasesByUpdatedAt = `-- name: ListReleasesByUpdatedAt :many
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR (updated_at, version) > ($7::timestamptz, $6::text))
+ORDER BY updated_at, version
+LIMIT $8::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR (updated_at, version) < ($7::timestamptz, $6::text))
+ORDER BY updated_at DESC, version DESC
+LIMIT $8::int)
+`
+
+type ListReleasesByUpdatedAtParams struct {
+	Descending   bool               `json:"descending"`
+	Platform     string             `json:"platform"`
+	Since        pgtype.Date        `json:"since"`
+	Until        pgtype.Date        `json:"until"`
+	Statuses     []string           `json:"statuses"`
+	AfterVersion string             `json:"after_version"`
+	AfterTime    pgtype.Timestamptz `json:"after_time"`
+	PageLimit    int32              `json:"page_limit"`
+}
+
+// Like ListReleasesByVersion, by last update with version breaking ties.
+func (q *Queries) ListReleasesByUpdatedAt(ctx context.Context, arg ListReleasesByUpdatedAtParams) ([]Release, error) {
+	rows, err := q.db.Query(ctx, listReleasesByUpdatedAt,
+		arg.Descending,
+		arg.Platform,
+		arg.Since,
+		arg.Until,
+		arg.Statuses,
+		arg.AfterVersion,
+		arg.AfterTime,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Release
+	for rows.Next() {
+		var i Release
+		if err := rows.Scan(
+			&i.Version,
+			&i.FromVer,
+			&i.Platform,
+			&i.ReleaseDate,
+			&i.SubmittedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.Locked,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReleasesByVersion = `-- name: ListReleasesByVersion :many
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR version > $6::text)
+ORDER BY version
+LIMIT $7::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE $1::bool
+  AND platform = $2 AND deleted_at IS NULL
+  AND ($3::date IS NULL OR release_date >= $3::date)
+  AND ($4::date IS NULL OR release_date <= $4::date)
+  AND (cardinality($5::text[]) = 0 OR status = ANY($5::text[]))
+  AND ($6::text = '' OR version < $6::text)
+ORDER BY version DESC
+LIMIT $7::int)
 `
 
-type GetVersionsByPlatformRow struct {
-	Version     string             `json:"version"`
-	FromVer     string             `json:"from_ver"`
-	ReleaseDate string             `json:"release_date"`
-	SubmittedBy string             `json:"submitted_by"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+type ListReleasesByVersionParams struct {
+	Descending   bool        `json:"descending"`
+	Platform     string      `json:"platform"`
+	Since        pgtype.Date `json:"since"`
+	Until        pgtype.Date `json:"until"`
+	Statuses     []string    `json:"statuses"`
+	AfterVersion string      `json:"after_version"`
+	PageLimit    int32       `json:"page_limit"`
 }
 
-func (q *Queries) GetVersionsByPlatform(ctx context.Context, platform string) ([]GetVersionsByPlatformRow, error) {
-	rows, err := q.db.Query(ctx, getVersionsByPlatform, platform)
+// Keyset page over a platform's live releases by version. since, until and
+// statuses narrow the versions list; the releases list leaves them empty.
+// Each order has its own branch so it reads idx_releases_platform_version in
+// index order; only the requested one returns rows.
+func (q *Queries) ListReleasesByVersion(ctx context.Context, arg ListReleasesByVersionParams) ([]Release, error) {
+	rows, err := q.db.Query(ctx, listReleasesByVersion,
+		arg.Descending,
+		arg.Platform,
+		arg.Since,
+		arg.Until,
+		arg.Statuses,
+		arg.AfterVersion,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetVersionsByPlatformRow
+	var items []Release
 	for rows.Next() {
-		var i GetVersionsByPlatformRow
+		var i Release
 		if err := rows.Scan(
 			&i.Version,
 			&i.FromVer,
+			&i.Platform,
 			&i.ReleaseDate,
 			&i.SubmittedBy,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.Locked,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -142,25 +536,169 @@ func (q *Queries) GetVersionsByPlatform(ctx context.Context, platform string) ([
 	return items, nil
 }
 
+const lockRelease = `-- name: LockRelease :one
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE version = $1
+FOR UPDATE
+`
+
+// Unlike GetRelease this also returns soft-deleted rows, so restores and
+// re-submissions can lock them.
+func (q *Queries) LockRelease(ctx context.Context, version string) (Release, error) {
+	row := q.db.QueryRow(ctx, lockRelease, version)
+	var i Release
+	err := row.Scan(
+		&i.Version,
+		&i.FromVer,
+		&i.Platform,
+		&i.ReleaseDate,
+		&i.SubmittedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Status,
+		&i.Locked,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const purgeDeletedReleases = `-- name: PurgeDeletedReleases :many
+DELETE FROM releases
+WHERE deleted_at < $1::timestamptz
+RETURNING version, platform
+`
+
+type PurgeDeletedReleasesRow struct {
+	Version  string `json:"version"`
+	Platform string `json:"platform"`
+}
+
+func (q *Queries) PurgeDeletedReleases(ctx context.Context, deletedBefore pgtype.Timestamptz) ([]PurgeDeletedReleasesRow, error) {
+	rows, err := q.db.Query(ctx, purgeDeletedReleases, deletedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PurgeDeletedReleasesRow
+	for rows.Next() {
+		var i PurgeDeletedReleasesRow
+		if err := rows.Scan(&i.Version, &i.Platform); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreRelease = `-- name: RestoreRelease :exec
+UPDATE releases SET deleted_at = NULL, updated_at = now() WHERE version = $1
+`
+
+func (q *Queries) RestoreRelease(ctx context.Context, version string) error {
+	_, err := q.db.Exec(ctx, restoreRelease, version)
+	return err
+}
+
+const setReleaseLocked = `-- name: SetReleaseLocked :exec
+UPDATE releases SET locked = $2, updated_at = now() WHERE version = $1
+`
+
+type SetReleaseLockedParams struct {
+	Version string `json:"version"`
+	Locked  bool   `json:"locked"`
+}
+
+func (q *Queries) SetReleaseLocked(ctx context.Context, arg SetReleaseLockedParams) error {
+	_, err := q.db.Exec(ctx, setReleaseLocked, arg.Version, arg.Locked)
+	return err
+}
+
+const softDeleteRelease = `-- name: SoftDeleteRelease :exec
+UPDATE releases SET deleted_at = now(), updated_at = now() WHERE version = $1
+`
+
+func (q *Queries) SoftDeleteRelease(ctx context.Context, version string) error {
+	_, err := q.db.Exec(ctx, softDeleteRelease, version)
+	return err
+}
+
+const touchRelease = `-- name: TouchRelease :exec
+UPDATE releases SET updated_at = now() WHERE version = $1
+`
+
+func (q *Queries) TouchRelease(ctx context.Context, version string) error {
+	_, err := q.db.Exec(ctx, touchRelease, version)
+	return err
+}
+
+const updateReleaseMetadata = `-- name: UpdateReleaseMetadata :exec
+UPDATE releases SET
+    release_date = CASE WHEN $1::bool THEN $2::date ELSE release_date END,
+    submitted_by = COALESCE($3, submitted_by),
+    updated_at = now()
+WHERE version = $4
+`
+
+type UpdateReleaseMetadataParams struct {
+	SetReleaseDate bool        `json:"set_release_date"`
+	ReleaseDate    pgtype.Date `json:"release_date"`
+	SubmittedBy    pgtype.Text `json:"submitted_by"`
+	Version        string      `json:"version"`
+}
+
+func (q *Queries) UpdateReleaseMetadata(ctx context.Context, arg UpdateReleaseMetadataParams) error {
+	_, err := q.db.Exec(ctx, updateReleaseMetadata,
+		arg.SetReleaseDate,
+		arg.ReleaseDate,
+		arg.SubmittedBy,
+		arg.Version,
+	)
+	return err
+}
+
+const updateReleaseStatus = `-- name: UpdateReleaseStatus :exec
+UPDATE releases SET status = $2, updated_at = now() WHERE version = $1
+`
+
+type UpdateReleaseStatusParams struct {
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+func (q *Queries) UpdateReleaseStatus(ctx context.Context, arg UpdateReleaseStatusParams) error {
+	_, err := q.db.Exec(ctx, updateReleaseStatus, arg.Version, arg.Status)
+	return err
+}
+
 const upsertRelease = `-- name: UpsertRelease :exec
-INSERT INTO releases (version, from_ver, platform, release_date, submitted_by, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, now(), now())
+INSERT INTO releases (version, from_ver, platform, release_date, submitted_by, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, now(), now())
 ON CONFLICT (version) DO UPDATE SET
     from_ver = EXCLUDED.from_ver,
     platform = EXCLUDED.platform,
     release_date = EXCLUDED.release_date,
     submitted_by = EXCLUDED.submitted_by,
+    status = CASE WHEN releases.deleted_at IS NULL THEN releases.status ELSE EXCLUDED.status END,
+    locked = releases.locked AND releases.deleted_at IS NULL,
+    deleted_at = NULL,
     updated_at = now()
 `
 
 type UpsertReleaseParams struct {
-	Version     string `json:"version"`
-	FromVer     string `json:"from_ver"`
-	Platform    string `json:"platform"`
-	ReleaseDate string `json:"release_date"`
-	SubmittedBy string `json:"submitted_by"`
+	Version     string      `json:"version"`
+	FromVer     string      `json:"from_ver"`
+	Platform    string      `json:"platform"`
+	ReleaseDate pgtype.Date `json:"release_date"`
+	SubmittedBy string      `json:"submitted_by"`
+	Status      string      `json:"status"`
 }
 
+// A submission for a soft-deleted version recreates it: the old status and
+// lock are discarded along with the deletion marker.
 func (q *Queries) UpsertRelease(ctx context.Context, arg UpsertReleaseParams) error {
 	_, err := q.db.Exec(ctx, upsertRelease,
 		arg.Version,
@@ -168,6 +706,7 @@ func (q *Queries) UpsertRelease(ctx context.Context, arg UpsertReleaseParams) er
 		arg.Platform,
 		arg.ReleaseDate,
 		arg.SubmittedBy,
+		arg.Status,
 	)
 	return err
 }
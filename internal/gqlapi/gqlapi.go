@@ -0,0 +1,158 @@
+// Package gqlapi serves a read-only GraphQL view of the release trees so a
+// dashboard can walk platforms, releases and their jiras in one request
+// instead of one REST call per step. The schema is in schema.graphql.
+package gqlapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/graph-gophers/graphql-go"
+	"jiraiya/internal/service"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Limits on a single query. The depth is checked before the query runs. The
+// object limit is a complexity budget: every release and jira a query
+// resolves counts against it, and resolvers fail once it is spent.
+const (
+	maxDepth   = 12
+	maxObjects = 5000
+)
+
+// errTooComplex is reported by the first resolver past the object limit.
+var errTooComplex = errors.New("query is too complex")
+
+// Handler serves GraphQL queries over HTTP POST.
+type Handler struct {
+	schema *graphql.Schema
+	svc    service.Service
+	log    *slog.Logger
+
+	maxObjects int64
+}
+
+// New creates a Handler backed by svc.
+func New(svc service.Service, log *slog.Logger) *Handler {
+	return &Handler{
+		schema:     graphql.MustParseSchema(schemaSDL, &queryResolver{}, graphql.MaxDepth(maxDepth)),
+		svc:        svc,
+		log:        log,
+		maxObjects: maxObjects,
+	}
+}
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ServeHTTP runs one query. As usual for GraphQL, query and resolver errors
+// are reported in the response's errors list with a 200 status.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid json: " + err.Error()})
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), requestKey{}, h.newRequest())
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+type requestKey struct{}
+
+// request holds the state of one query: its loaders and its object budget.
+type request struct {
+	svc service.Service
+	log *slog.Logger
+
+	releases     *loader[service.ReleaseOutput]
+	jiras        *loader[service.JiraOutput]
+	jiraReleases *loader[[]string]
+
+	objects    atomic.Int64
+	maxObjects int64
+}
+
+func (h *Handler) newRequest() *request {
+	req := &request{svc: h.svc, log: h.log, maxObjects: h.maxObjects}
+	req.releases = newLoader(func(ctx context.Context, versions []string) (map[string]service.ReleaseOutput, error) {
+		rels, err := h.svc.GetReleasesByVersions(ctx, versions)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]service.ReleaseOutput, len(rels))
+		for _, r := range rels {
+			out[r.Version] = r
+		}
+		return out, nil
+	})
+	req.jiras = newLoader(func(ctx context.Context, ids []string) (map[string]service.JiraOutput, error) {
+		jiras, err := h.svc.GetJirasByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		out := make(map[string]service.JiraOutput, len(jiras))
+		for _, j := range jiras {
+			out[j.ID] = j
+		}
+		return out, nil
+	})
+	// Every release a jira lookup names is about to be resolved, so queue
+	// them all for the release loader's next fetch.
+	req.jiraReleases = newLoader(func(ctx context.Context, ids []string) (map[string][]string, error) {
+		versions, err := h.svc.GetReleaseVersionsByJiras(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, vs := range versions {
+			req.releases.want(vs...)
+		}
+		return versions, nil
+	})
+	return req
+}
+
+func requestFrom(ctx context.Context) *request {
+	return ctx.Value(requestKey{}).(*request)
+}
+
+// charge spends n objects of the budget.
+func (req *request) charge(n int) error {
+	if req.objects.Add(int64(n)) > req.maxObjects {
+		return fmt.Errorf("%w; it resolves more than %d releases and jiras", errTooComplex, req.maxObjects)
+	}
+	return nil
+}
+
+// resolverError passes on errors the service reports to callers on purpose
+// and hides everything else behind a logged internal error.
+func (req *request) resolverError(err error, msg string, args ...any) error {
+	var ve *service.ValidationError
+	var te *service.TransitionError
+	switch {
+	case errors.As(err, &ve), errors.As(err, &te):
+		return err
+	case errors.Is(err, service.ErrNotFound):
+		return errors.New("not found")
+	case errors.Is(err, service.ErrForbidden):
+		return errors.New("forbidden")
+	}
+	req.log.Error(msg, append(args, "error", err)...)
+	return errors.New("internal error")
+}
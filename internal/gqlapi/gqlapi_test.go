@@ -0,0 +1,269 @@
+package gqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"jiraiya/internal/service"
+)
+
+// fakeService holds one platform's tree and counts the batch lookups. Methods
+// the handler does not call panic on the nil embedded interface.
+type fakeService struct {
+	service.Service
+
+	mu    sync.Mutex
+	nodes map[string]*service.ReleaseNode
+	root  string
+	calls map[string]int
+}
+
+// newFakeService builds the tree 1.0 -> 1.1 -> 1.1.<n>, where 1.0 also has
+// the child 1.2. Each release adds one jira named after it, and 1.1 and 1.2
+// both link the shared jira S-1.
+func newFakeService(width int) *fakeService {
+	f := &fakeService{nodes: make(map[string]*service.ReleaseNode), root: "1.0", calls: make(map[string]int)}
+	add := func(version, parent string, jiras ...string) {
+		f.nodes[version] = &service.ReleaseNode{Version: version, Parent: parent, Jiras: append([]string{"J-" + version}, jiras...)}
+		if p := f.nodes[parent]; p != nil {
+			p.Children = append(p.Children, version)
+		}
+	}
+	add("1.0", "")
+	add("1.1", "1.0", "S-1")
+	add("1.2", "1.0", "S-1")
+	for i := range width {
+		add(fmt.Sprintf("1.1.%d", i), "1.1")
+	}
+	for _, n := range f.nodes {
+		for p := n.Parent; p != ""; p = f.nodes[p].Parent {
+			n.Ancestors = append(n.Ancestors, p)
+		}
+	}
+	return f
+}
+
+func (f *fakeService) count(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[name]++
+}
+
+func (f *fakeService) ListPlatforms(ctx context.Context) ([]string, error) {
+	return []string{"ios"}, nil
+}
+
+func (f *fakeService) GetTreeInfo(ctx context.Context, platform string, tq service.TreeQuery) (*service.TreeInfo, error) {
+	return &service.TreeInfo{Platform: platform, Root: f.root}, nil
+}
+
+func (f *fakeService) GetReleaseNode(ctx context.Context, platform, version string) (*service.ReleaseNode, error) {
+	n, ok := f.nodes[version]
+	if !ok || platform != "ios" {
+		return nil, service.ErrNotFound
+	}
+	return n, nil
+}
+
+func (f *fakeService) GetReleasesByVersions(ctx context.Context, versions []string) ([]service.ReleaseOutput, error) {
+	f.count("releases")
+	var out []service.ReleaseOutput
+	for _, v := range versions {
+		if n, ok := f.nodes[v]; ok {
+			out = append(out, service.ReleaseOutput{Version: v, FromVer: n.Parent, Platform: "ios", Status: service.StatusGA})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeService) GetJirasByIDs(ctx context.Context, ids []string) ([]service.JiraOutput, error) {
+	f.count("jiras")
+	out := make([]service.JiraOutput, len(ids))
+	for i, id := range ids {
+		out[i] = service.JiraOutput{ID: id, Title: "title " + id}
+	}
+	return out, nil
+}
+
+func (f *fakeService) GetReleaseVersionsByJiras(ctx context.Context, ids []string) (map[string][]string, error) {
+	f.count("jira releases")
+	out := make(map[string][]string)
+	for _, id := range ids {
+		for _, n := range f.nodes {
+			for _, j := range n.Jiras {
+				if j == id {
+					out[id] = append(out[id], n.Version)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeService) GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string, opts service.DiffOptions) ([]service.JiraOutput, error) {
+	if _, ok := f.nodes[fromVer]; !ok {
+		return nil, fmt.Errorf("calc changes: version %q not found", fromVer)
+	}
+	return []service.JiraOutput{{ID: "J-" + toVer, Release: toVer}}, nil
+}
+
+type gqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func query(t *testing.T, h http.Handler, q string) gqlResponse {
+	t.Helper()
+	body, _ := json.Marshal(map[string]string{"query": q})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(string(body))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	var resp gqlResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %s: %v", rec.Body, err)
+	}
+	return resp
+}
+
+func newTestHandler(svc service.Service) *Handler {
+	return New(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestTreeWalk(t *testing.T) {
+	svc := newFakeService(2)
+	resp := query(t, newTestHandler(svc), `{
+		platform(name: "ios") {
+			root {
+				version
+				children {
+					version
+					parent { version }
+					jiras { id releases { version } }
+					children { version ancestors { version } }
+				}
+			}
+		}
+	}`)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors %v", resp.Errors)
+	}
+
+	var data struct {
+		Platform struct {
+			Root struct {
+				Version  string
+				Children []struct {
+					Version string
+					Parent  struct{ Version string }
+					Jiras   []struct {
+						ID       string
+						Releases []struct{ Version string }
+					}
+					Children []struct {
+						Version   string
+						Ancestors []struct{ Version string }
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	root := data.Platform.Root
+	if root.Version != "1.0" || len(root.Children) != 2 {
+		t.Fatalf("unexpected root %+v", root)
+	}
+	r11 := root.Children[0]
+	if r11.Version != "1.1" || r11.Parent.Version != "1.0" || len(r11.Children) != 2 {
+		t.Fatalf("unexpected release %+v", r11)
+	}
+	if a := r11.Children[1].Ancestors; len(a) != 2 || a[0].Version != "1.1" || a[1].Version != "1.0" {
+		t.Fatalf("expected ancestors nearest first, got %+v", a)
+	}
+	if j := r11.Jiras; len(j) != 2 || j[0].ID != "J-1.1" || j[1].ID != "S-1" || len(j[1].Releases) != 2 {
+		t.Fatalf("unexpected jiras %+v", j)
+	}
+}
+
+func TestBatching(t *testing.T) {
+	// The root release costs one lookup and everything the tree can name
+	// beneath it another, however wide the tree is.
+	for _, width := range []int{1, 40} {
+		svc := newFakeService(width)
+		resp := query(t, newTestHandler(svc), `{
+			release(version: "1.0") {
+				children {
+					jiras { id releases { version } }
+					children { version parent { version } jiras { id } }
+				}
+			}
+		}`)
+		if len(resp.Errors) > 0 {
+			t.Fatalf("unexpected errors %v", resp.Errors)
+		}
+		want := map[string]int{"releases": 2, "jiras": 1, "jira releases": 1}
+		for name, n := range want {
+			if svc.calls[name] != n {
+				t.Errorf("width %d: expected %d %s lookups, got %d", width, n, name, svc.calls[name])
+			}
+		}
+	}
+}
+
+func TestMissing(t *testing.T) {
+	resp := query(t, newTestHandler(newFakeService(1)), `{ release(version: "9.9") { version } platform(name: "android") { name } }`)
+	if len(resp.Errors) > 0 || string(resp.Data) != `{"release":null,"platform":null}` {
+		t.Fatalf("expected nulls, got %s %v", resp.Data, resp.Errors)
+	}
+
+	resp = query(t, newTestHandler(newFakeService(1)), `{ release(version: "1.1") { jirasSince(from: "9.9") { id } } }`)
+	if len(resp.Errors) != 1 || resp.Errors[0].Message != "internal error" {
+		t.Fatalf("expected an internal error, got %v", resp.Errors)
+	}
+}
+
+func TestJirasSince(t *testing.T) {
+	resp := query(t, newTestHandler(newFakeService(1)), `{ release(version: "1.1.0") { jirasSince(from: "1.0") { id introducedIn { version status } } } }`)
+	want := `{"release":{"jirasSince":[{"id":"J-1.1.0","introducedIn":{"version":"1.1.0","status":"ga"}}]}}`
+	if len(resp.Errors) > 0 || string(resp.Data) != want {
+		t.Fatalf("expected %s, got %s %v", want, resp.Data, resp.Errors)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	t.Run("depth", func(t *testing.T) {
+		q := "{ release(version: \"1.0\") { " + strings.Repeat("children { ", maxDepth) + "version" + strings.Repeat(" }", maxDepth) + " } }"
+		resp := query(t, newTestHandler(newFakeService(1)), q)
+		if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "exceeds max depth") {
+			t.Fatalf("expected a depth error, got %v", resp.Errors)
+		}
+	})
+
+	t.Run("objects", func(t *testing.T) {
+		h := newTestHandler(newFakeService(10))
+		h.maxObjects = 8
+		resp := query(t, h, `{ release(version: "1.1") { children { version } } }`)
+		if len(resp.Errors) != 1 || !strings.Contains(resp.Errors[0].Message, "too complex") {
+			t.Fatalf("expected a complexity error, got %v", resp.Errors)
+		}
+
+		// The budget is per request.
+		resp = query(t, h, `{ release(version: "1.1") { version } }`)
+		if len(resp.Errors) > 0 {
+			t.Fatalf("unexpected errors %v", resp.Errors)
+		}
+	})
+}
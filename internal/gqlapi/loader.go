@@ -0,0 +1,78 @@
+package gqlapi
+
+import (
+	"context"
+	"sync"
+)
+
+// loader batches lookups by key for one request. Resolvers queue every key
+// the next level of the query will need with want, and the first load fetches
+// all queued keys in one call, so a level of the tree costs one query however
+// wide it is. Keys the fetch does not return are remembered as missing.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu      sync.Mutex
+	pending map[string]bool
+	values  map[string]V
+	fetched map[string]bool
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		pending: make(map[string]bool),
+		values:  make(map[string]V),
+		fetched: make(map[string]bool),
+	}
+}
+
+// want queues keys for the next fetch.
+func (l *loader[V]) want(keys ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, k := range keys {
+		if !l.fetched[k] {
+			l.pending[k] = true
+		}
+	}
+}
+
+// prime stores a value that was read some other way.
+func (l *loader[V]) prime(key string, v V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.values[key] = v
+	l.fetched[key] = true
+	delete(l.pending, key)
+}
+
+// load returns the value for key, fetching it together with every queued key
+// if it has not been fetched yet. The lock is held across the fetch so
+// concurrent loads wait for it instead of issuing their own.
+func (l *loader[V]) load(ctx context.Context, key string) (V, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.fetched[key] {
+		l.pending[key] = true
+		keys := make([]string, 0, len(l.pending))
+		for k := range l.pending {
+			keys = append(keys, k)
+		}
+		values, err := l.fetch(ctx, keys)
+		if err != nil {
+			var zero V
+			return zero, false, err
+		}
+		for _, k := range keys {
+			if v, ok := values[k]; ok {
+				l.values[k] = v
+			}
+			l.fetched[k] = true
+		}
+		clear(l.pending)
+	}
+	v, ok := l.values[key]
+	return v, ok, nil
+}
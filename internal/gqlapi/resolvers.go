@@ -0,0 +1,320 @@
+package gqlapi
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go"
+	"jiraiya/internal/service"
+)
+
+type queryResolver struct{}
+
+func (*queryResolver) Platforms(ctx context.Context) ([]*platformResolver, error) {
+	req := requestFrom(ctx)
+	names, err := req.svc.ListPlatforms(ctx)
+	if err != nil {
+		return nil, req.resolverError(err, "list platforms failed")
+	}
+	out := make([]*platformResolver, len(names))
+	for i, name := range names {
+		out[i] = &platformResolver{req: req, name: name}
+	}
+	return out, nil
+}
+
+func (*queryResolver) Platform(ctx context.Context, args struct{ Name string }) (*platformResolver, error) {
+	req := requestFrom(ctx)
+	names, err := req.svc.ListPlatforms(ctx)
+	if err != nil {
+		return nil, req.resolverError(err, "list platforms failed")
+	}
+	if !slices.Contains(names, args.Name) {
+		return nil, nil
+	}
+	return &platformResolver{req: req, name: args.Name}, nil
+}
+
+func (*queryResolver) Release(ctx context.Context, args struct{ Version string }) (*releaseResolver, error) {
+	return requestFrom(ctx).release(ctx, args.Version)
+}
+
+func (*queryResolver) Jira(ctx context.Context, args struct{ ID string }) (*jiraResolver, error) {
+	req := requestFrom(ctx)
+	jiras, err := req.jiraList(ctx, []string{args.ID})
+	if err != nil || len(jiras) == 0 {
+		return nil, err
+	}
+	return jiras[0], nil
+}
+
+type platformResolver struct {
+	req  *request
+	name string
+}
+
+func (p *platformResolver) Name() string { return p.name }
+
+func (p *platformResolver) Root(ctx context.Context) (*releaseResolver, error) {
+	info, err := p.req.svc.GetTreeInfo(ctx, p.name, service.TreeQuery{Depth: 1})
+	if err != nil {
+		return nil, p.req.resolverError(err, "get tree failed", "platform", p.name)
+	}
+	return p.req.release(ctx, info.Root)
+}
+
+// Releases reads the whole platform in one query and primes the release
+// loader with it, so nothing under it needs to look the releases up again.
+func (p *platformResolver) Releases(ctx context.Context) ([]*releaseResolver, error) {
+	rels, err := p.req.svc.GetReleases(ctx, "", p.name)
+	if err != nil {
+		return nil, p.req.resolverError(err, "get releases failed", "platform", p.name)
+	}
+	versions := make([]string, len(rels))
+	for i, r := range rels {
+		p.req.releases.prime(r.Version, r)
+		versions[i] = r.Version
+	}
+	sort.Strings(versions)
+	return p.req.releaseList(ctx, versions)
+}
+
+type releaseResolver struct {
+	req *request
+	r   service.ReleaseOutput
+}
+
+func (r *releaseResolver) Version() string     { return r.r.Version }
+func (r *releaseResolver) FromVer() string     { return r.r.FromVer }
+func (r *releaseResolver) ReleaseDate() string { return r.r.ReleaseDate }
+func (r *releaseResolver) SubmittedBy() string { return r.r.SubmittedBy }
+func (r *releaseResolver) Status() string      { return r.r.Status }
+func (r *releaseResolver) Locked() bool        { return r.r.Locked }
+
+func (r *releaseResolver) Platform() *platformResolver {
+	return &platformResolver{req: r.req, name: r.r.Platform}
+}
+
+func (r *releaseResolver) node(ctx context.Context) (*service.ReleaseNode, error) {
+	n, err := r.req.svc.GetReleaseNode(ctx, r.r.Platform, r.r.Version)
+	if err != nil {
+		return nil, r.req.resolverError(err, "get release node failed", "version", r.r.Version)
+	}
+	return n, nil
+}
+
+func (r *releaseResolver) Parent(ctx context.Context) (*releaseResolver, error) {
+	n, err := r.node(ctx)
+	if err != nil || n.Parent == "" {
+		return nil, err
+	}
+	return r.req.release(ctx, n.Parent)
+}
+
+func (r *releaseResolver) Children(ctx context.Context) ([]*releaseResolver, error) {
+	n, err := r.node(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.req.releaseList(ctx, n.Children)
+}
+
+func (r *releaseResolver) Ancestors(ctx context.Context) ([]*releaseResolver, error) {
+	n, err := r.node(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.req.releaseList(ctx, n.Ancestors)
+}
+
+func (r *releaseResolver) Jiras(ctx context.Context) ([]*jiraResolver, error) {
+	n, err := r.node(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := slices.Clone(n.Jiras)
+	sort.Strings(ids)
+	return r.req.jiraList(ctx, ids)
+}
+
+func (r *releaseResolver) JirasSince(ctx context.Context, args struct {
+	From   string
+	GaOnly bool
+}) ([]*jiraResolver, error) {
+	jiras, err := r.req.svc.GetJirasBetweenVersions(ctx, args.From, r.r.Version, service.DiffOptions{GAOnly: args.GaOnly})
+	if err != nil {
+		return nil, r.req.resolverError(err, "get jiras failed", "from", args.From, "to", r.r.Version)
+	}
+	if err := r.req.charge(len(jiras)); err != nil {
+		return nil, err
+	}
+	out := make([]*jiraResolver, len(jiras))
+	for i, j := range jiras {
+		out[i] = &jiraResolver{req: r.req, j: j}
+	}
+	r.req.prefetchJiras(ctx, out)
+	return out, nil
+}
+
+type jiraResolver struct {
+	req *request
+	j   service.JiraOutput
+}
+
+func (j *jiraResolver) ID() string       { return j.j.ID }
+func (j *jiraResolver) Title() string    { return j.j.Title }
+func (j *jiraResolver) Domain() string   { return j.j.Domain }
+func (j *jiraResolver) Impact() string   { return j.j.Impact }
+func (j *jiraResolver) Relnotes() string { return j.j.Relnotes }
+
+func (j *jiraResolver) IntroducedIn(ctx context.Context) (*releaseResolver, error) {
+	if j.j.Release == "" {
+		return nil, nil
+	}
+	return j.req.release(ctx, j.j.Release)
+}
+
+func (j *jiraResolver) Releases(ctx context.Context) ([]*releaseResolver, error) {
+	versions, _, err := j.req.jiraReleases.load(ctx, j.j.ID)
+	if err != nil {
+		return nil, j.req.resolverError(err, "get jira releases failed", "jira", j.j.ID)
+	}
+	return j.req.releaseList(ctx, versions)
+}
+
+// release resolves a single release, or nil if it does not exist.
+func (req *request) release(ctx context.Context, version string) (*releaseResolver, error) {
+	rels, err := req.releaseList(ctx, []string{version})
+	if err != nil || len(rels) == 0 {
+		return nil, err
+	}
+	return rels[0], nil
+}
+
+// releaseList resolves versions in order, skipping any that no longer exist,
+// and queues what the query selects beneath them.
+func (req *request) releaseList(ctx context.Context, versions []string) ([]*releaseResolver, error) {
+	if err := req.charge(len(versions)); err != nil {
+		return nil, err
+	}
+	req.releases.want(versions...)
+	out := make([]*releaseResolver, 0, len(versions))
+	for _, v := range versions {
+		r, ok, err := req.releases.load(ctx, v)
+		if err != nil {
+			return nil, req.resolverError(err, "get releases failed")
+		}
+		if ok {
+			out = append(out, &releaseResolver{req: req, r: r})
+		}
+	}
+	req.prefetchReleases(ctx, out)
+	return out, nil
+}
+
+// jiraList resolves ids in order, skipping any that do not exist, and queues
+// what the query selects beneath them.
+func (req *request) jiraList(ctx context.Context, ids []string) ([]*jiraResolver, error) {
+	if err := req.charge(len(ids)); err != nil {
+		return nil, err
+	}
+	req.jiras.want(ids...)
+	out := make([]*jiraResolver, 0, len(ids))
+	for _, id := range ids {
+		j, ok, err := req.jiras.load(ctx, id)
+		if err != nil {
+			return nil, req.resolverError(err, "get jiras failed")
+		}
+		if ok {
+			out = append(out, &jiraResolver{req: req, j: j})
+		}
+	}
+	req.prefetchJiras(ctx, out)
+	return out, nil
+}
+
+// prefetchReleases queues everything beneath rels that the query selects
+// and the in-memory tree can name: the releases reached through parent,
+// children and ancestors at any depth and the jiras they add. ctx must be the
+// context of the field that returned rels. The first load of each kind then
+// fetches the whole selection in one query, however the resolvers below are
+// scheduled.
+func (req *request) prefetchReleases(ctx context.Context, rels []*releaseResolver) {
+	names := graphql.SelectedFieldNames(ctx)
+	if len(names) == 0 {
+		return
+	}
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		selected[name] = true
+	}
+	refs := make([]service.VersionRef, len(rels))
+	for i, r := range rels {
+		refs[i] = service.VersionRef{Platform: r.r.Platform, Version: r.r.Version}
+	}
+	// A query past the budget fails anyway, so stop queueing there rather
+	// than fetch a whole platform for it.
+	remaining := int(req.maxObjects)
+	req.prefetchTree(ctx, selected, "", refs, &remaining)
+}
+
+func (req *request) prefetchTree(ctx context.Context, selected map[string]bool, prefix string, refs []service.VersionRef, remaining *int) {
+	next := make(map[string]map[service.VersionRef]bool)
+	for _, ref := range refs {
+		n, err := req.svc.GetReleaseNode(ctx, ref.Platform, ref.Version)
+		if err != nil {
+			// The field reports the error when it resolves.
+			continue
+		}
+		if selected[prefix+"jiras"] {
+			req.jiras.want(n.Jiras...)
+			*remaining -= len(n.Jiras)
+		}
+		if selected[prefix+"jiras.releases"] {
+			req.jiraReleases.want(n.Jiras...)
+		}
+		related := map[string][]string{"children": n.Children, "ancestors": n.Ancestors}
+		if n.Parent != "" {
+			related["parent"] = []string{n.Parent}
+		}
+		for field, versions := range related {
+			if !selected[prefix+field] {
+				continue
+			}
+			if next[field] == nil {
+				next[field] = make(map[service.VersionRef]bool)
+			}
+			for _, v := range versions {
+				next[field][service.VersionRef{Platform: ref.Platform, Version: v}] = true
+			}
+		}
+	}
+
+	for field, set := range next {
+		*remaining -= len(set)
+		if *remaining < 0 {
+			return
+		}
+		level := make([]service.VersionRef, 0, len(set))
+		for ref := range set {
+			req.releases.want(ref.Version)
+			level = append(level, ref)
+		}
+		req.prefetchTree(ctx, selected, prefix+field+".", level, remaining)
+	}
+}
+
+// prefetchJiras queues the releases the query selects beneath jiras.
+func (req *request) prefetchJiras(ctx context.Context, jiras []*jiraResolver) {
+	introducedIn := graphql.HasSelectedField(ctx, "introducedIn")
+	releases := graphql.HasSelectedField(ctx, "releases")
+	for _, j := range jiras {
+		if introducedIn && j.j.Release != "" {
+			req.releases.want(j.j.Release)
+		}
+		if releases {
+			req.jiraReleases.want(j.j.ID)
+		}
+	}
+}
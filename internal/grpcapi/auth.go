@@ -0,0 +1,83 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"jiraiya/internal/service"
+	"jiraiya/pkg/pb/jiraiyav1"
+)
+
+// methodRoles is the role each RPC requires, matching the HTTP routes. A
+// method missing from the map is refused.
+var methodRoles = map[string]string{
+	jiraiyav1.ReleaseService_SubmitRelease_FullMethodName:           service.RoleSubmitter,
+	jiraiyav1.ReleaseService_DeleteRelease_FullMethodName:           service.RoleAdmin,
+	jiraiyav1.ReleaseService_GetVersions_FullMethodName:             service.RoleReader,
+	jiraiyav1.ReleaseService_GetJirasBetweenVersions_FullMethodName: service.RoleReader,
+	jiraiyav1.ReleaseService_WatchReleases_FullMethodName:           service.RoleReader,
+}
+
+func (s *Server) authUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) authStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize resolves the bearer token in the "authorization" metadata key and
+// checks its role against the method, returning ctx with the caller's
+// identity.
+func (s *Server) authorize(ctx context.Context, method string) (context.Context, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			if scheme, t, ok := strings.Cut(v[0], " "); ok && strings.EqualFold(scheme, "Bearer") {
+				token = strings.TrimSpace(t)
+			}
+		}
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	id, err := s.svc.Authenticate(ctx, token)
+	if errors.Is(err, service.ErrUnauthorized) {
+		return nil, status.Error(codes.Unauthenticated, "invalid or revoked token")
+	}
+	if err != nil {
+		s.log.Error("authenticate failed", "method", method, "error", err)
+		return nil, status.Error(codes.Internal, "internal error")
+	}
+	role, ok := methodRoles[method]
+	if !ok {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed", method)
+	}
+	if !id.Can(role) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires the %s role", method, role)
+	}
+	return service.WithIdentity(ctx, id), nil
+}
+
+// identityStream overrides a stream's context with one carrying the caller.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
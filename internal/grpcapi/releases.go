@@ -0,0 +1,222 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"jiraiya/internal/service"
+	"jiraiya/pkg/pb/jiraiyav1"
+)
+
+// watchBatchSize is how many events WatchReleases reads per query while it
+// catches up.
+const watchBatchSize = 100
+
+func (s *Server) SubmitRelease(ctx context.Context, req *jiraiyav1.SubmitReleaseRequest) (*jiraiyav1.SubmitReleaseResponse, error) {
+	r := req.GetRelease()
+	sub := service.ReleaseSubmission{
+		Release: service.ReleaseInfo{
+			Version:     r.GetVersion(),
+			FromVer:     r.GetFromVer(),
+			Platform:    r.GetPlatform(),
+			ReleaseDate: r.GetReleaseDate(),
+			SubmittedBy: r.GetSubmittedBy(),
+			Status:      r.GetStatus(),
+		},
+		Changes: make([]service.JiraInput, len(req.GetChanges())),
+	}
+	for i, j := range req.GetChanges() {
+		sub.Changes[i] = service.JiraInput{
+			ID:       j.GetId(),
+			Title:    j.GetTitle(),
+			Domain:   j.GetDomain(),
+			Impact:   j.GetImpact(),
+			Relnotes: j.GetRelnotes(),
+		}
+	}
+
+	res, err := s.svc.SubmitRelease(ctx, sub, writeOptions(req.GetOptions()))
+	if err != nil {
+		return nil, s.statusError(err, "submit release failed", "version", sub.Release.Version)
+	}
+	out := &jiraiyav1.SubmitReleaseResponse{Etag: res.ETag, Replayed: res.Replayed}
+	for _, w := range res.Warnings {
+		out.Warnings = append(out.Warnings, &jiraiyav1.MetadataConflict{JiraId: w.JiraID, Field: w.Field, Old: w.Old, New: w.New})
+	}
+	return out, nil
+}
+
+func (s *Server) DeleteRelease(ctx context.Context, req *jiraiyav1.DeleteReleaseRequest) (*jiraiyav1.DeleteReleaseResponse, error) {
+	if req.GetVersion() == "" {
+		return nil, status.Error(codes.InvalidArgument, "version is required")
+	}
+	if err := s.svc.DeleteRelease(ctx, req.GetVersion(), writeOptions(req.GetOptions())); err != nil {
+		return nil, s.statusError(err, "delete release failed", "version", req.GetVersion())
+	}
+	return &jiraiyav1.DeleteReleaseResponse{}, nil
+}
+
+func (s *Server) GetVersions(ctx context.Context, req *jiraiyav1.GetVersionsRequest) (*jiraiyav1.GetVersionsResponse, error) {
+	if req.GetPlatform() == "" {
+		return nil, status.Error(codes.InvalidArgument, "platform is required")
+	}
+	vq := service.VersionQuery{
+		Statuses: req.GetStatuses(),
+		ListQuery: service.ListQuery{
+			Sort:   req.GetSort(),
+			Order:  req.GetOrder(),
+			Limit:  int(req.GetLimit()),
+			Cursor: req.GetCursor(),
+		},
+	}
+	var err error
+	if vq.Since, err = parseDate("since", req.GetSince()); err != nil {
+		return nil, err
+	}
+	if vq.Until, err = parseDate("until", req.GetUntil()); err != nil {
+		return nil, err
+	}
+
+	list, err := s.svc.GetVersions(ctx, req.GetPlatform(), vq)
+	if err != nil {
+		return nil, s.statusError(err, "get versions failed", "platform", req.GetPlatform())
+	}
+	out := &jiraiyav1.GetVersionsResponse{Total: list.Total, NextCursor: list.NextCursor}
+	for _, v := range list.Versions {
+		out.Versions = append(out.Versions, &jiraiyav1.Version{
+			Version:     v.Version,
+			FromVer:     v.FromVer,
+			ReleaseDate: v.ReleaseDate,
+			SubmittedBy: v.SubmittedBy,
+			Status:      v.Status,
+		})
+	}
+	return out, nil
+}
+
+func (s *Server) GetJirasBetweenVersions(ctx context.Context, req *jiraiyav1.GetJirasBetweenVersionsRequest) (*jiraiyav1.GetJirasBetweenVersionsResponse, error) {
+	if req.GetFromVersion() == "" || req.GetToVersion() == "" {
+		return nil, status.Error(codes.InvalidArgument, "from_version and to_version are required")
+	}
+	opts := service.DiffOptions{AsOfTo: req.GetAsOfTo(), GAOnly: req.GetGaOnly()}
+	jiras, err := s.svc.GetJirasBetweenVersions(ctx, req.GetFromVersion(), req.GetToVersion(), opts)
+	if err != nil {
+		return nil, s.statusError(err, "get jiras failed", "from", req.GetFromVersion(), "to", req.GetToVersion())
+	}
+	return &jiraiyav1.GetJirasBetweenVersionsResponse{Jiras: jirasOut(jiras)}, nil
+}
+
+// WatchReleases polls the release event log and sends each new event. With
+// no after_id it starts at the newest event, so only later changes are sent.
+func (s *Server) WatchReleases(req *jiraiyav1.WatchReleasesRequest, stream jiraiyav1.ReleaseService_WatchReleasesServer) error {
+	ctx := stream.Context()
+	after := req.GetAfterId()
+	if after < 0 {
+		return status.Error(codes.InvalidArgument, "after_id must not be negative")
+	}
+	if after == 0 {
+		latest, err := s.svc.LatestReleaseEventID(ctx)
+		if err != nil {
+			return s.statusError(err, "get latest release event failed")
+		}
+		after = latest
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		// Catch up on everything committed since the last poll before
+		// waiting again.
+		for {
+			events, err := s.svc.ListReleaseEvents(ctx, service.EventQuery{
+				Platform: req.GetPlatform(),
+				AfterID:  after,
+				Limit:    watchBatchSize,
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return status.FromContextError(ctx.Err()).Err()
+				}
+				return s.statusError(err, "list release events failed", "after_id", after)
+			}
+			for _, e := range events {
+				if err := stream.Send(releaseEvent(e)); err != nil {
+					return err
+				}
+				after = e.ID
+			}
+			if len(events) < watchBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.done:
+			return status.Error(codes.Unavailable, "server is shutting down; reconnect with after_id to resume")
+		case <-ticker.C:
+		}
+	}
+}
+
+func writeOptions(o *jiraiyav1.WriteOptions) service.WriteOptions {
+	return service.WriteOptions{
+		IfMatch:        o.GetIfMatch(),
+		IdempotencyKey: o.GetIdempotencyKey(),
+		Override:       o.GetAdminOverride(),
+	}
+}
+
+// parseDate parses an optional YYYY-MM-DD request field.
+func parseDate(name, v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(service.DateLayout, v)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "%s must be a YYYY-MM-DD date", name)
+	}
+	return t, nil
+}
+
+func releaseOut(r service.ReleaseOutput) *jiraiyav1.Release {
+	return &jiraiyav1.Release{
+		Version:     r.Version,
+		FromVer:     r.FromVer,
+		Platform:    r.Platform,
+		ReleaseDate: r.ReleaseDate,
+		SubmittedBy: r.SubmittedBy,
+		Status:      r.Status,
+		Locked:      r.Locked,
+	}
+}
+
+func jirasOut(jiras []service.JiraOutput) []*jiraiyav1.Jira {
+	out := make([]*jiraiyav1.Jira, len(jiras))
+	for i, j := range jiras {
+		out[i] = &jiraiyav1.Jira{
+			Id:       j.ID,
+			Title:    j.Title,
+			Domain:   j.Domain,
+			Impact:   j.Impact,
+			Relnotes: j.Relnotes,
+			Release:  j.Release,
+		}
+	}
+	return out
+}
+
+func releaseEvent(e service.ReleaseEvent) *jiraiyav1.ReleaseEvent {
+	return &jiraiyav1.ReleaseEvent{
+		Id:         e.ID,
+		Type:       e.Type,
+		OccurredAt: timestamppb.New(e.OccurredAt),
+		Actor:      e.Actor,
+		Release:    releaseOut(e.Release),
+		Jiras:      jirasOut(e.Jiras),
+	}
+}
@@ -0,0 +1,140 @@
+// Package grpcapi serves part of the service over gRPC for build systems that
+// cannot use the HTTP API. The protocol is defined in
+// proto/jiraiya/v1/jiraiya.proto.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"jiraiya/internal/service"
+	"jiraiya/pkg/pb/jiraiyav1"
+)
+
+// defaultPollInterval is how often WatchReleases checks the event log for
+// new events.
+const defaultPollInterval = time.Second
+
+// Server is the gRPC server for the ReleaseService.
+type Server struct {
+	jiraiyav1.UnimplementedReleaseServiceServer
+
+	svc          service.Service
+	log          *slog.Logger
+	grpc         *grpc.Server
+	pollInterval time.Duration
+
+	// done is closed by Shutdown to end open WatchReleases streams, which
+	// would otherwise keep a graceful stop waiting forever.
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// New creates a Server backed by svc.
+func New(svc service.Service, log *slog.Logger) *Server {
+	s := &Server{
+		svc:          svc,
+		log:          log,
+		pollInterval: defaultPollInterval,
+		done:         make(chan struct{}),
+	}
+	s.grpc = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.logUnary, s.authUnary),
+		grpc.ChainStreamInterceptor(s.logStream, s.authStream),
+	)
+	jiraiyav1.RegisterReleaseServiceServer(s.grpc, s)
+	return s
+}
+
+// Serve accepts connections on lis until Shutdown is called, after which it
+// returns nil.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.grpc.Serve(lis)
+}
+
+// Shutdown ends open watch streams and waits for in-flight calls to finish.
+// If ctx ends first the remaining calls are cancelled and ctx's error is
+// returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() { close(s.done) })
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *Server) logUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.logCall(info.FullMethod, start, err)
+	return resp, err
+}
+
+func (s *Server) logStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	s.logCall(info.FullMethod, start, err)
+	return err
+}
+
+func (s *Server) logCall(method string, start time.Time, err error) {
+	s.log.Info("rpc",
+		"method", method,
+		"code", status.Code(err).String(),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// statusError converts errors the service reports to callers on purpose into
+// gRPC statuses, mirroring the HTTP API's status codes. Validation problems
+// are attached as a BadRequest detail whose field is the jira id for per-jira
+// problems. Any other error is logged and reported as an internal error.
+func (s *Server) statusError(err error, msg string, args ...any) error {
+	var ve *service.ValidationError
+	var te *service.TransitionError
+	switch {
+	case errors.As(err, &ve):
+		br := &errdetails.BadRequest{}
+		for _, d := range ve.Details {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: d.ID, Description: d.Reason})
+		}
+		st, detErr := status.New(codes.InvalidArgument, "validation failed").WithDetails(br)
+		if detErr != nil {
+			return status.Error(codes.InvalidArgument, ve.Error())
+		}
+		return st.Err()
+	case errors.As(err, &te):
+		return status.Error(codes.FailedPrecondition, te.Error())
+	case errors.Is(err, service.ErrNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case errors.Is(err, service.ErrPreconditionFailed):
+		return status.Error(codes.Aborted, "release was modified (if_match mismatch)")
+	case errors.Is(err, service.ErrIdempotencyConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrReleaseLocked):
+		return status.Error(codes.FailedPrecondition, "release is locked; set admin_override with a reason to change it")
+	case errors.Is(err, service.ErrUnauthorized):
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	case errors.Is(err, service.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+	s.log.Error(msg, append(args, "error", err)...)
+	return status.Error(codes.Internal, "internal error")
+}
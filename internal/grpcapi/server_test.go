@@ -0,0 +1,299 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"jiraiya/internal/service"
+	"jiraiya/pkg/pb/jiraiyav1"
+)
+
+// fakeService records what the server passes it. Methods the server does not
+// call panic on the nil embedded interface.
+type fakeService struct {
+	service.Service
+
+	mu        sync.Mutex
+	sub       service.ReleaseSubmission
+	caller    *service.Identity
+	opts      service.WriteOptions
+	vq        service.VersionQuery
+	submitErr error
+	events    []service.ReleaseEvent
+}
+
+// Authenticate accepts a token named after each role, e.g. "admin-token".
+func (f *fakeService) Authenticate(ctx context.Context, token string) (*service.Identity, error) {
+	role, ok := strings.CutSuffix(token, "-token")
+	if !ok || !service.ValidRole(role) {
+		return nil, service.ErrUnauthorized
+	}
+	return &service.Identity{Name: "ci-" + role, Role: role}, nil
+}
+
+func (f *fakeService) SubmitRelease(ctx context.Context, sub service.ReleaseSubmission, opts service.WriteOptions) (*service.SubmitResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sub, f.opts, f.caller = sub, opts, service.IdentityFrom(ctx)
+	if f.submitErr != nil {
+		return nil, f.submitErr
+	}
+	return &service.SubmitResult{
+		ETag:     `"3"`,
+		Warnings: []service.MetadataConflict{{JiraID: "A-1", Field: "title", Old: "old", New: "new"}},
+	}, nil
+}
+
+func (f *fakeService) GetVersions(ctx context.Context, platform string, vq service.VersionQuery) (*service.VersionList, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.vq = vq
+	return &service.VersionList{
+		Versions:   []service.VersionInfo{{Version: "1.0.0", Status: service.StatusGA}},
+		Total:      7,
+		NextCursor: "next",
+	}, nil
+}
+
+func (f *fakeService) LatestReleaseEventID(ctx context.Context) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return int64(len(f.events)), nil
+}
+
+func (f *fakeService) ListReleaseEvents(ctx context.Context, eq service.EventQuery) ([]service.ReleaseEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []service.ReleaseEvent
+	for _, e := range f.events {
+		if e.ID > eq.AfterID && (eq.Platform == "" || e.Release.Platform == eq.Platform) && len(out) < eq.Limit {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeService) addEvent(platform, version string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, service.ReleaseEvent{
+		ID: int64(len(f.events) + 1),
+		WebhookEvent: service.WebhookEvent{
+			Type:       service.EventReleaseSubmitted,
+			OccurredAt: time.Now(),
+			Release:    service.ReleaseOutput{Platform: platform, Version: version},
+			Jiras:      []service.JiraOutput{{ID: version + "-J"}},
+		},
+	})
+}
+
+func newTestServer(t *testing.T, svc *fakeService) (*Server, jiraiyav1.ReleaseServiceClient) {
+	t.Helper()
+	srv := New(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	srv.pollInterval = 10 * time.Millisecond
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return srv, jiraiyav1.NewReleaseServiceClient(conn)
+}
+
+// asRole returns a context that calls with the fake token for role.
+func asRole(role string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+role+"-token")
+}
+
+func TestSubmitRelease(t *testing.T) {
+	svc := &fakeService{}
+	_, c := newTestServer(t, svc)
+	ctx := asRole(service.RoleSubmitter)
+
+	resp, err := c.SubmitRelease(ctx, &jiraiyav1.SubmitReleaseRequest{
+		Release: &jiraiyav1.Release{Version: "1.1.0", FromVer: "1.0.0", Platform: "ios", ReleaseDate: "2026-01-02"},
+		Changes: []*jiraiyav1.Jira{{Id: "A-1", Title: "new"}},
+		Options: &jiraiyav1.WriteOptions{IfMatch: `"2"`, IdempotencyKey: "k"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Etag != `"3"` || len(resp.Warnings) != 1 || resp.Warnings[0].JiraId != "A-1" {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	if svc.sub.Release.FromVer != "1.0.0" || svc.sub.Release.ReleaseDate != "2026-01-02" || len(svc.sub.Changes) != 1 || svc.sub.Changes[0].Title != "new" {
+		t.Fatalf("unexpected submission %+v", svc.sub)
+	}
+	if svc.opts.IfMatch != `"2"` || svc.opts.IdempotencyKey != "k" {
+		t.Fatalf("unexpected options %+v", svc.opts)
+	}
+	if svc.caller == nil || svc.caller.Name != "ci-submitter" {
+		t.Fatalf("expected the caller's identity, got %+v", svc.caller)
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			err  error
+			code codes.Code
+		}{
+			{&service.ValidationError{Details: []service.ValidationDetail{{ID: "A-1", Reason: "bad"}}}, codes.InvalidArgument},
+			{service.ErrNotFound, codes.NotFound},
+			{service.ErrPreconditionFailed, codes.Aborted},
+			{service.ErrIdempotencyConflict, codes.AlreadyExists},
+			{service.ErrReleaseLocked, codes.FailedPrecondition},
+			{io.ErrUnexpectedEOF, codes.Internal},
+		} {
+			svc.mu.Lock()
+			svc.submitErr = tc.err
+			svc.mu.Unlock()
+			_, err := c.SubmitRelease(ctx, &jiraiyav1.SubmitReleaseRequest{Release: &jiraiyav1.Release{Version: "1"}})
+			st := status.Convert(err)
+			if st.Code() != tc.code {
+				t.Errorf("%v: expected %v, got %v", tc.err, tc.code, st.Code())
+			}
+			if tc.code == codes.InvalidArgument {
+				br, ok := st.Details()[0].(*errdetails.BadRequest)
+				if !ok || br.FieldViolations[0].Field != "A-1" || br.FieldViolations[0].Description != "bad" {
+					t.Errorf("expected a BadRequest detail, got %v", st.Details())
+				}
+			}
+		}
+	})
+}
+
+func TestGetVersions(t *testing.T) {
+	svc := &fakeService{}
+	_, c := newTestServer(t, svc)
+	ctx := asRole(service.RoleReader)
+
+	resp, err := c.GetVersions(ctx, &jiraiyav1.GetVersionsRequest{Platform: "ios", Since: "2026-01-01", Statuses: []string{"ga"}, Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Versions) != 1 || resp.Total != 7 || resp.NextCursor != "next" {
+		t.Fatalf("unexpected response %v", resp)
+	}
+	if svc.vq.Since.Format(service.DateLayout) != "2026-01-01" || !svc.vq.Until.IsZero() || svc.vq.Limit != 5 || svc.vq.Statuses[0] != "ga" {
+		t.Fatalf("unexpected query %+v", svc.vq)
+	}
+
+	for _, req := range []*jiraiyav1.GetVersionsRequest{
+		{},
+		{Platform: "ios", Until: "tomorrow"},
+	} {
+		if _, err := c.GetVersions(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%v: expected InvalidArgument, got %v", req, err)
+		}
+	}
+}
+
+func TestWatchReleases(t *testing.T) {
+	svc := &fakeService{}
+	svc.addEvent("ios", "1.0.0")
+	svc.addEvent("android", "1.0.0")
+	srv, c := newTestServer(t, svc)
+	ctx, cancel := context.WithTimeout(asRole(service.RoleReader), 10*time.Second)
+	defer cancel()
+
+	recv := func(t *testing.T, stream jiraiyav1.ReleaseService_WatchReleasesClient, want ...int64) {
+		t.Helper()
+		for _, id := range want {
+			e, err := stream.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Id != id || e.Release.GetVersion() == "" || len(e.Jiras) != 1 || e.OccurredAt == nil {
+				t.Fatalf("expected event %d, got %v", id, e)
+			}
+		}
+	}
+
+	t.Run("resumes after an id", func(t *testing.T) {
+		stream, err := c.WatchReleases(ctx, &jiraiyav1.WatchReleasesRequest{AfterId: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		recv(t, stream, 2)
+	})
+
+	// Without after_id only events committed after the call are sent, and
+	// the platform filter applies.
+	stream, err := c.WatchReleases(ctx, &jiraiyav1.WatchReleasesRequest{Platform: "ios"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The server picks its starting point when the call arrives; let it do
+	// so before adding events.
+	time.Sleep(50 * time.Millisecond)
+	svc.addEvent("android", "1.1.0")
+	svc.addEvent("ios", "1.1.0")
+	recv(t, stream, 4)
+
+	// Shutdown ends open streams instead of waiting for them.
+	done := make(chan error, 1)
+	go func() { done <- srv.Shutdown(ctx) }()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable on shutdown, got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("expected a graceful shutdown, got %v", err)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	_, c := newTestServer(t, &fakeService{})
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+		call func(ctx context.Context) error
+		code codes.Code
+	}{
+		{"no token", context.Background(), func(ctx context.Context) error {
+			_, err := c.GetVersions(ctx, &jiraiyav1.GetVersionsRequest{Platform: "ios"})
+			return err
+		}, codes.Unauthenticated},
+		{"unknown token", asRole("nobody"), func(ctx context.Context) error {
+			_, err := c.GetVersions(ctx, &jiraiyav1.GetVersionsRequest{Platform: "ios"})
+			return err
+		}, codes.Unauthenticated},
+		{"reader submits", asRole(service.RoleReader), func(ctx context.Context) error {
+			_, err := c.SubmitRelease(ctx, &jiraiyav1.SubmitReleaseRequest{Release: &jiraiyav1.Release{Version: "1"}})
+			return err
+		}, codes.PermissionDenied},
+		{"submitter deletes", asRole(service.RoleSubmitter), func(ctx context.Context) error {
+			_, err := c.DeleteRelease(ctx, &jiraiyav1.DeleteReleaseRequest{Version: "1"})
+			return err
+		}, codes.PermissionDenied},
+		{"stream without token", context.Background(), func(ctx context.Context) error {
+			stream, err := c.WatchReleases(ctx, &jiraiyav1.WatchReleasesRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.Unauthenticated},
+	} {
+		if err := tc.call(tc.ctx); status.Code(err) != tc.code {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.code, err)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package handler
 
 import (
 	"net/http"
+
+	"jiraiya/internal/service"
 )
 
 func (h *Handler) getTree(w http.ResponseWriter, r *http.Request) {
@@ -11,8 +13,18 @@ func (h *Handler) getTree(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	info, err := h.svc.GetTreeInfo(r.Context(), platform)
+	tq := service.TreeQuery{Root: r.URL.Query().Get("root")}
+	var err error
+	if tq.Depth, err = intParam(r, "depth"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	info, err := h.svc.GetTreeInfo(r.Context(), platform, tq)
 	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
 		h.log.Error("get tree failed", "platform", platform, "error", err)
 		writeError(w, http.StatusInternalServerError, "internal error")
 		return
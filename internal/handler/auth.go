@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"jiraiya/internal/service"
+)
+
+// publicPaths are served without a token.
+var publicPaths = map[string]bool{
+	"/api/openapi.json": true,
+}
+
+// feedPrefix starts the feed routes. Feed readers cannot send an
+// Authorization header, so these also take the token as a token query param.
+const feedPrefix = "/api/feeds/"
+
+// authenticate resolves the bearer token on every request outside
+// publicPaths and stores the caller's identity in the request context.
+// Requests without a valid token get a 401.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if publicPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token, ok := bearerToken(r)
+		fromQuery := false
+		if !ok && strings.HasPrefix(r.URL.Path, feedPrefix) {
+			token = strings.TrimSpace(r.URL.Query().Get("token"))
+			ok, fromQuery = token != "", true
+		}
+		if !ok {
+			writeUnauthorized(w, "missing bearer token")
+			return
+		}
+		id, err := h.svc.Authenticate(r.Context(), token)
+		if errors.Is(err, service.ErrUnauthorized) {
+			writeUnauthorized(w, "invalid or revoked token")
+			return
+		}
+		if err != nil {
+			h.log.Error("authenticate failed", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if fromQuery {
+			// A token in a URL ends up in logs and reader configs, so it
+			// only ever reads.
+			id = readOnly(id)
+		}
+		next.ServeHTTP(w, r.WithContext(service.WithIdentity(r.Context(), id)))
+	})
+}
+
+// requireRole rejects callers whose role does not include role with a 403.
+func requireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !service.IdentityFrom(r.Context()).Can(role) {
+				writeError(w, http.StatusForbidden, "this endpoint requires the "+role+" role")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// readOnly returns a copy of id whose role and grants are capped at reader.
+func readOnly(id *service.Identity) *service.Identity {
+	out := &service.Identity{TokenID: id.TokenID, Name: id.Name, Role: service.RoleReader, Grants: make(map[string]string, len(id.Grants))}
+	for platform := range id.Grants {
+		out.Grants[platform] = service.RoleReader
+	}
+	return out
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+func writeUnauthorized(w http.ResponseWriter, msg string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="jiraiya"`)
+	writeError(w, http.StatusUnauthorized, msg)
+}
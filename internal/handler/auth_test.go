@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuthentication(t *testing.T) {
+	srv := httptest.NewServer(New(&stubService{}, slog.New(slog.NewTextHandler(io.Discard, nil))).Routes())
+	t.Cleanup(srv.Close)
+
+	send := func(t *testing.T, method, path, auth, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	t.Run("rejects missing and unknown tokens", func(t *testing.T) {
+		for _, auth := range []string{"", "Bearer ", "Basic YTpi", "Bearer nope"} {
+			resp := send(t, http.MethodGet, "/api/versions?platform=ios", auth, "")
+			if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+				t.Errorf("%q: expected 401 with a challenge, got %d", auth, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("serves the document without a token", func(t *testing.T) {
+		if resp := send(t, http.MethodGet, "/api/openapi.json", "", ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("takes feed tokens from the query", func(t *testing.T) {
+		if resp := send(t, http.MethodGet, "/api/feeds/ios.atom?token=reader-token", "", ""); resp.StatusCode != http.StatusOK {
+			t.Fatalf("feed with token param: expected 200, got %d", resp.StatusCode)
+		}
+		if resp := send(t, http.MethodGet, "/api/feeds/ios.atom", "", ""); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("feed without a token: expected 401, got %d", resp.StatusCode)
+		}
+		// Only the feed routes read the param, and only for reading
+		if resp := send(t, http.MethodGet, "/api/versions?platform=ios&token=admin-token", "", ""); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("token param outside feeds: expected 401, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("enforces roles", func(t *testing.T) {
+		submission := `{"release":{"version":"1","platform":"ios"}}`
+		tests := []struct {
+			role, method, path, body string
+			want                     int
+		}{
+			{"reader", http.MethodGet, "/api/versions?platform=ios", "", http.StatusOK},
+			{"reader", http.MethodPut, "/api/releases", submission, http.StatusForbidden},
+			{"submitter", http.MethodPut, "/api/releases", submission, http.StatusOK},
+			{"submitter", http.MethodDelete, "/api/releases/1", "", http.StatusForbidden},
+			{"submitter", http.MethodGet, "/api/admin/tokens", "", http.StatusForbidden},
+			{"admin", http.MethodGet, "/api/versions?platform=ios", "", http.StatusOK},
+		}
+		for _, tc := range tests {
+			resp := send(t, tc.method, tc.path, "Bearer "+tc.role+"-token", tc.body)
+			if resp.StatusCode != tc.want {
+				t.Errorf("%s %s %s: expected %d, got %d", tc.role, tc.method, tc.path, tc.want, resp.StatusCode)
+			}
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"jiraiya/internal/service"
+)
+
+func (h *Handler) compareVersions(w http.ResponseWriter, r *http.Request) {
+	left, err := versionRefParam(r, "left")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	right, err := versionRefParam(r, "right")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cmp, err := h.svc.CompareVersions(r.Context(), left, right)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("compare versions failed", "left", left, "right", right, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, cmp)
+}
+
+// versionRefParam parses a required platform:version query param.
+func versionRefParam(r *http.Request, name string) (service.VersionRef, error) {
+	platform, version, ok := strings.Cut(r.URL.Query().Get(name), ":")
+	if !ok || platform == "" || version == "" {
+		return service.VersionRef{}, fmt.Errorf("%s must be platform:version", name)
+	}
+	return service.VersionRef{Platform: platform, Version: version}, nil
+}
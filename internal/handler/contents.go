@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"jiraiya/internal/service"
+)
+
+func (h *Handler) getReleaseContents(w http.ResponseWriter, r *http.Request) {
+	version := chi.URLParam(r, "version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	cq := service.ContentsQuery{
+		Domain: r.URL.Query().Get("domain"),
+		Impact: r.URL.Query().Get("impact"),
+	}
+	var err error
+	if cq.Limit, err = intParam(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if cq.Offset, err = intParam(r, "offset"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.svc.GetReleaseContents(r.Context(), version, cq)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("get release contents failed", "version", version, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
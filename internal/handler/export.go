@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jiraiya/internal/service"
+)
+
+// Export formats selected by the format query param or the Accept header.
+const (
+	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// exportPageSize is the page size used when streaming a whole list.
+const exportPageSize = 500
+
+// exportFormat picks the response format. The format query param wins over
+// the Accept header; anything else yields plain JSON.
+func exportFormat(r *http.Request) (string, error) {
+	switch f := r.URL.Query().Get("format"); f {
+	case formatJSON, formatCSV, formatNDJSON:
+		return f, nil
+	case "":
+	default:
+		return "", fmt.Errorf("format must be one of json, csv, ndjson")
+	}
+	accept := r.Header.Get("Accept")
+	switch {
+	case strings.Contains(accept, "text/csv"):
+		return formatCSV, nil
+	case strings.Contains(accept, "application/x-ndjson"):
+		return formatNDJSON, nil
+	}
+	return formatJSON, nil
+}
+
+// exportWriter streams rows as CSV or NDJSON, flushing after every batch so
+// large lists never sit in memory. Headers are sent on the first row, so an
+// error before then can still be reported normally.
+type exportWriter struct {
+	w       http.ResponseWriter
+	format  string
+	columns []string
+	csv     *csv.Writer
+	enc     *json.Encoder
+	started bool
+}
+
+func newExportWriter(w http.ResponseWriter, format string, columns []string) *exportWriter {
+	return &exportWriter{w: w, format: format, columns: columns}
+}
+
+func (e *exportWriter) start() error {
+	e.started = true
+	if e.format == formatCSV {
+		e.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		e.w.WriteHeader(http.StatusOK)
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(e.columns)
+	}
+	e.w.Header().Set("Content-Type", "application/x-ndjson")
+	e.w.WriteHeader(http.StatusOK)
+	e.enc = json.NewEncoder(e.w)
+	return nil
+}
+
+// write emits one row. record holds the CSV cells in column order and v is
+// the value encoded for NDJSON.
+func (e *exportWriter) write(record []string, v any) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.csv != nil {
+		return e.csv.Write(record)
+	}
+	return e.enc.Encode(v)
+}
+
+// flush pushes buffered rows to the client. An empty export still gets its
+// headers and, for CSV, the header row.
+func (e *exportWriter) flush() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := http.NewResponseController(e.w).Flush(); err != nil && err != http.ErrNotSupported {
+		return err
+	}
+	return nil
+}
+
+// Column orders for each exported row type. These are part of the export
+// contract; append new columns at the end.
+var (
+	jiraColumns    = []string{"id", "title", "impact", "domain", "relnotes", "release"}
+	releaseColumns = []string{"version", "from_ver", "platform", "release_date", "submitted_by", "status", "locked"}
+	versionColumns = []string{"version", "from_ver", "release_date", "submitted_by", "status"}
+)
+
+func jiraRecord(j service.JiraOutput) []string {
+	return []string{j.ID, j.Title, j.Impact, j.Domain, j.Relnotes, j.Release}
+}
+
+func releaseRecord(r service.ReleaseOutput) []string {
+	return []string{r.Version, r.FromVer, r.Platform, r.ReleaseDate, r.SubmittedBy, r.Status, strconv.FormatBool(r.Locked)}
+}
+
+func versionRecord(v service.VersionInfo) []string {
+	return []string{v.Version, v.FromVer, v.ReleaseDate, v.SubmittedBy, v.Status}
+}
+
+// exportRows streams an in-memory list.
+func exportRows[T any](w http.ResponseWriter, format string, columns []string, rows []T, record func(T) []string) error {
+	ew := newExportWriter(w, format, columns)
+	for _, row := range rows {
+		if err := ew.write(record(row), row); err != nil {
+			return err
+		}
+	}
+	return ew.flush()
+}
+
+// exportPages streams every page of a keyset list. fetch returns one page and
+// the cursor of the next, which is empty on the last page. An error on the
+// first page is returned before anything is written; later errors can only
+// cut the stream short.
+func exportPages[T any](w http.ResponseWriter, format string, columns []string, record func(T) []string, fetch func(cursor string) ([]T, string, error)) (started bool, err error) {
+	ew := newExportWriter(w, format, columns)
+	cursor := ""
+	for {
+		rows, next, err := fetch(cursor)
+		if err != nil {
+			return ew.started, err
+		}
+		for _, row := range rows {
+			if err := ew.write(record(row), row); err != nil {
+				return true, err
+			}
+		}
+		if err := ew.flush(); err != nil {
+			return true, err
+		}
+		if next == "" {
+			return true, nil
+		}
+		cursor = next
+	}
+}
+
+// exportFailed reports a failed export. Until the stream has started the
+// error gets a normal response; afterwards it can only be logged.
+func (h *Handler) exportFailed(w http.ResponseWriter, started bool, err error, msg string, args ...any) {
+	if !started && writeServiceError(w, err) {
+		return
+	}
+	h.log.Error(msg, append(args, "error", err)...)
+	if !started {
+		writeError(w, http.StatusInternalServerError, "internal error")
+	}
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"jiraiya/internal/service"
+)
+
+// entryContent renders a feed entry's jiras as HTML.
+var entryContent = template.Must(template.New("entry").Parse(
+	`<p>{{.Version}}{{if .FromVer}} (from {{.FromVer}}){{end}}{{if .ReleaseDate}}, released {{.ReleaseDate}}{{end}}</p>` +
+		`{{if .Jiras}}<ul>{{range .Jiras}}<li><strong>{{.ID}}</strong> {{.Title}}{{if .Relnotes}}: {{.Relnotes}}{{end}}</li>{{end}}</ul>` +
+		`{{else}}<p>No new jiras.</p>{{end}}`))
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// getFeed serves /api/feeds/{platform}.atom and /api/feeds/{platform}.rss.
+func (h *Handler) getFeed(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "feed")
+	platform, kind, ok := cutLast(name, ".")
+	if !ok || platform == "" || (kind != "atom" && kind != "rss") {
+		writeError(w, http.StatusNotFound, "feed must be {platform}.atom or {platform}.rss")
+		return
+	}
+
+	fq := service.FeedQuery{Branch: r.URL.Query().Get("branch")}
+	var err error
+	if fq.Limit, err = intParam(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	feed, err := h.svc.GetFeed(r.Context(), platform, fq)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("get feed failed", "platform", platform, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	var doc any
+	var contentType string
+	if kind == "atom" {
+		doc, err = atomDocument(feed, requestURL(r))
+		contentType = "application/atom+xml; charset=utf-8"
+	} else {
+		doc, err = rssDocument(feed, requestURL(r))
+		contentType = "application/rss+xml; charset=utf-8"
+	}
+	if err != nil {
+		h.log.Error("render feed failed", "platform", platform, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		h.log.Error("write feed failed", "platform", platform, "error", err)
+	}
+}
+
+func atomDocument(feed *service.Feed, self string) (*atomFeed, error) {
+	doc := &atomFeed{
+		ID:      feedID(feed.Platform),
+		Title:   feed.Platform + " releases",
+		Updated: feedTime(feed.Updated).Format(time.RFC3339),
+		Author:  atomAuthor{Name: "jiraiya"},
+		Link:    atomLink{Href: self, Rel: "self"},
+	}
+	for _, e := range feed.Entries {
+		body, err := renderEntry(e)
+		if err != nil {
+			return nil, err
+		}
+		entry := atomEntry{
+			ID:      entryID(feed.Platform, e.Version),
+			Title:   entryTitle(feed.Platform, e),
+			Updated: e.Updated.UTC().Format(time.RFC3339),
+			Content: atomContent{Type: "html", Body: body},
+		}
+		if e.SubmittedBy != "" {
+			entry.Author = &atomAuthor{Name: e.SubmittedBy}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return doc, nil
+}
+
+func rssDocument(feed *service.Feed, self string) (*rssFeed, error) {
+	doc := &rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         feed.Platform + " releases",
+			Link:          self,
+			Description:   "New " + feed.Platform + " releases and the jiras they ship",
+			LastBuildDate: feedTime(feed.Updated).Format(time.RFC1123Z),
+		},
+	}
+	for _, e := range feed.Entries {
+		body, err := renderEntry(e)
+		if err != nil {
+			return nil, err
+		}
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       entryTitle(feed.Platform, e),
+			GUID:        rssGUID{Value: entryID(feed.Platform, e.Version)},
+			PubDate:     e.Updated.UTC().Format(time.RFC1123Z),
+			Description: body,
+		})
+	}
+	return doc, nil
+}
+
+func renderEntry(e service.FeedEntry) (string, error) {
+	var buf bytes.Buffer
+	if err := entryContent.Execute(&buf, e); err != nil {
+		return "", fmt.Errorf("render entry %s: %w", e.Version, err)
+	}
+	return buf.String(), nil
+}
+
+func entryTitle(platform string, e service.FeedEntry) string {
+	title := fmt.Sprintf("%s %s", platform, e.Version)
+	if e.Status != "" && e.Status != service.StatusGA {
+		title += " (" + e.Status + ")"
+	}
+	return title
+}
+
+// feedID and entryID are stable identifiers that never change for a platform
+// or version, so readers can de-duplicate entries across fetches.
+func feedID(platform string) string {
+	return "urn:jiraiya:feed:" + url.PathEscape(platform)
+}
+
+func entryID(platform, version string) string {
+	return "urn:jiraiya:release:" + url.PathEscape(platform) + ":" + url.PathEscape(version)
+}
+
+// feedTime falls back to now for a feed with no entries.
+func feedTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now().UTC()
+	}
+	return t.UTC()
+}
+
+// requestURL reconstructs the absolute URL of the request for self links.
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
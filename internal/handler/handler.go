@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
+	"jiraiya/internal/gqlapi"
 	"jiraiya/internal/service"
 )
 
@@ -19,18 +20,67 @@ func New(svc service.Service, log *slog.Logger) *Handler {
 	return &Handler{svc: svc, log: log}
 }
 
-// Routes returns the chi router with all routes registered.
+// Routes returns the chi router with all routes registered. Every route but
+// the OpenAPI document needs a bearer token whose role includes the one its
+// group requires. Requests are then validated against the embedded OpenAPI
+// document before they reach a handler.
 func (h *Handler) Routes() http.Handler {
+	spec, err := loadSpec()
+	if err != nil {
+		// The document is embedded, so this only fails on a broken build.
+		panic(err)
+	}
+
 	r := chi.NewRouter()
 	r.Use(requestLogger(h.log))
+	r.Use(h.authenticate)
+	r.Use(validateRequests(spec, r))
+
+	r.Get("/api/openapi.json", h.getOpenAPI)
+
+	r.Group(func(r chi.Router) {
+		r.Use(requireRole(service.RoleReader))
+		r.Get("/api/releases", h.getReleases)
+		r.Get("/api/releases/{version}/history", h.getReleaseHistory)
+		r.Get("/api/releases/{version}/contents", h.getReleaseContents)
+		r.Get("/api/filters", h.getFilters)
+		r.Get("/api/versions", h.getVersions)
+		r.Get("/api/jiras", h.getJiras)
+		r.Get("/api/jiras/search", h.searchJiras)
+		r.Get("/api/jiras/{id}", h.getJira)
+		r.Get("/api/compare", h.compareVersions)
+		r.Get("/api/feeds/{feed}", h.getFeed)
+		r.Method(http.MethodPost, "/api/graphql", gqlapi.New(h.svc, h.log))
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(requireRole(service.RoleSubmitter))
+		r.Put("/api/releases", h.submitRelease)
+		r.Patch("/api/releases/{version}", h.updateRelease)
+		r.Post("/api/releases/{version}/transition", h.transitionRelease)
+		r.Post("/api/releases/{version}/jiras", h.addReleaseJira)
+		r.Delete("/api/releases/{version}/jiras/{id}", h.removeReleaseJira)
+		r.Patch("/api/jiras/{id}", h.updateJira)
+	})
 
-	r.Get("/api/releases", h.getReleases)
-	r.Put("/api/releases", h.submitRelease)
-	r.Delete("/api/releases/{version}", h.deleteRelease)
-	r.Get("/api/filters", h.getFilters)
-	r.Get("/api/versions", h.getVersions)
-	r.Get("/api/jiras", h.getJiras)
-	r.Get("/api/admin/tree", h.getTree)
+	r.Group(func(r chi.Router) {
+		r.Use(requireRole(service.RoleAdmin))
+		r.Delete("/api/releases/{version}", h.deleteRelease)
+		r.Put("/api/releases/{version}/lock", h.lockRelease)
+		r.Delete("/api/releases/{version}/lock", h.unlockRelease)
+		r.Post("/api/releases/{version}/restore", h.restoreRelease)
+		r.Post("/api/webhooks", h.createWebhook)
+		r.Get("/api/webhooks", h.listWebhooks)
+		r.Get("/api/webhooks/dead-letters", h.listDeadLetters)
+		r.Get("/api/webhooks/{id}", h.getWebhook)
+		r.Delete("/api/webhooks/{id}", h.deleteWebhook)
+		r.Get("/api/webhooks/{id}/deliveries", h.listWebhookDeliveries)
+		r.Post("/api/webhooks/deliveries/{id}/retry", h.retryWebhookDelivery)
+		r.Get("/api/admin/tree", h.getTree)
+		r.Post("/api/admin/tokens", h.createToken)
+		r.Get("/api/admin/tokens", h.listTokens)
+		r.Delete("/api/admin/tokens/{id}", h.revokeToken)
+	})
 
 	return r
 }
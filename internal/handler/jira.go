@@ -1,7 +1,13 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"jiraiya/internal/service"
 )
 
 func (h *Handler) getJiras(w http.ResponseWriter, r *http.Request) {
@@ -12,13 +18,44 @@ func (h *Handler) getJiras(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jiras, err := h.svc.GetJirasBetweenVersions(r.Context(), from, to)
+	var opts service.DiffOptions
+	switch asOf := r.URL.Query().Get("as_of"); asOf {
+	case "":
+	case "to":
+		opts.AsOfTo = true
+	default:
+		writeError(w, http.StatusBadRequest, "as_of must be \"to\" when set")
+		return
+	}
+
+	if v := r.URL.Query().Get("ga_only"); v != "" {
+		gaOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "ga_only must be true or false")
+			return
+		}
+		opts.GAOnly = gaOnly
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jiras, err := h.svc.GetJirasBetweenVersions(r.Context(), from, to, opts)
 	if err != nil {
 		h.log.Error("get jiras failed", "from", from, "to", to, "error", err)
 		writeError(w, http.StatusInternalServerError, "internal error")
 		return
 	}
 
+	if format != formatJSON {
+		if err := exportRows(w, format, jiraColumns, jiras, jiraRecord); err != nil {
+			h.log.Error("export jiras failed", "from", from, "to", to, "error", err)
+		}
+		return
+	}
 	writeJSON(w, http.StatusOK, jiras)
 }
 
@@ -31,6 +68,9 @@ func (h *Handler) getFilters(w http.ResponseWriter, r *http.Request) {
 
 	filters, err := h.svc.GetFilters(r.Context(), platform)
 	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
 		h.log.Error("get filters failed", "platform", platform, "error", err)
 		writeError(w, http.StatusInternalServerError, "internal error")
 		return
@@ -38,3 +78,84 @@ func (h *Handler) getFilters(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, filters)
 }
+
+func (h *Handler) getJira(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	jira, err := h.svc.GetJira(r.Context(), id)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("get jira failed", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jira)
+}
+
+func (h *Handler) searchJiras(w http.ResponseWriter, r *http.Request) {
+	js := service.JiraSearch{
+		Query:  r.URL.Query().Get("q"),
+		Domain: r.URL.Query().Get("domain"),
+		Impact: r.URL.Query().Get("impact"),
+	}
+	var err error
+	if js.Limit, err = intParam(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if js.Offset, err = intParam(r, "offset"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.svc.SearchJiras(r.Context(), js)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("search jiras failed", "q", js.Query, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (h *Handler) updateJira(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var patch service.JiraPatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	jira, err := h.svc.UpdateJira(r.Context(), id, patch)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("update jira failed", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jira)
+}
+
+// intParam parses an optional non-negative integer query param. A missing
+// param yields zero.
+func intParam(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return n, nil
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"jiraiya/internal/service"
+)
+
+// Pagination response headers. List bodies stay plain JSON arrays so existing
+// clients keep working.
+const (
+	headerTotalCount = "X-Total-Count"
+	headerNextCursor = "X-Next-Cursor"
+)
+
+// listQuery reads the shared limit, cursor, sort and order query params.
+func listQuery(r *http.Request) (service.ListQuery, error) {
+	lq := service.ListQuery{
+		Sort:   r.URL.Query().Get("sort"),
+		Order:  r.URL.Query().Get("order"),
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+	var err error
+	if lq.Limit, err = intParam(r, "limit"); err != nil {
+		return lq, err
+	}
+	return lq, nil
+}
+
+// writePage writes one page of a list. The total count and next cursor go in
+// headers; the body is the items, narrowed to the fields query param if set.
+func writePage(w http.ResponseWriter, r *http.Request, items any, total int64, next string) {
+	w.Header().Set(headerTotalCount, strconv.FormatInt(total, 10))
+	if next != "" {
+		w.Header().Set(headerNextCursor, next)
+	}
+
+	fields := r.URL.Query().Get("fields")
+	if fields == "" {
+		writeJSON(w, http.StatusOK, items)
+		return
+	}
+	selected, err := selectFields(items, strings.Split(fields, ","))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, selected)
+}
+
+// selectFields re-encodes a slice of objects keeping only the named JSON
+// fields. Unknown names are ignored.
+func selectFields(items any, fields []string) ([]map[string]json.RawMessage, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("marshal items: %w", err)
+	}
+	var all []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("unmarshal items: %w", err)
+	}
+
+	out := make([]map[string]json.RawMessage, len(all))
+	for i, item := range all {
+		out[i] = make(map[string]json.RawMessage, len(fields))
+		for _, f := range fields {
+			if v, ok := item[f]; ok {
+				out[i][f] = v
+			}
+		}
+	}
+	return out, nil
+}
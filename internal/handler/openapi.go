@@ -0,0 +1,209 @@
+package handler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// openAPIDocument is the OpenAPI 3.1 description of every route in Routes.
+// The validation middleware checks requests against it, so a route or field
+// added to the handlers must be added here too.
+//
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// apiSpec is the part of the OpenAPI document request validation reads.
+type apiSpec struct {
+	Paths      map[string]*pathItem `json:"paths"`
+	Components struct {
+		Schemas    map[string]*schema    `json:"schemas"`
+		Parameters map[string]*parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type pathItem struct {
+	Parameters []*parameter `json:"parameters"`
+	Get        *operation   `json:"get"`
+	Put        *operation   `json:"put"`
+	Post       *operation   `json:"post"`
+	Patch      *operation   `json:"patch"`
+	Delete     *operation   `json:"delete"`
+}
+
+type operation struct {
+	Parameters  []*parameter `json:"parameters"`
+	RequestBody *struct {
+		Required bool `json:"required"`
+		Content  map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+type parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required"`
+	Schema   *schema `json:"schema"`
+}
+
+// schema is the subset of JSON Schema the document uses.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaType         `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	AllOf                []*schema          `json:"allOf"`
+	Enum                 []string           `json:"enum"`
+	Format               string             `json:"format"`
+	Minimum              *float64           `json:"minimum"`
+	MaxLength            *int               `json:"maxLength"`
+	MaxItems             *int               `json:"maxItems"`
+}
+
+// schemaType holds a schema's type, which JSON Schema writes either as a
+// single name or as a list such as ["string", "null"].
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = schemaType{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// has reports whether the type allows name. A schema without a type allows
+// anything.
+func (t schemaType) has(name string) bool {
+	if len(t) == 0 {
+		return true
+	}
+	for _, n := range t {
+		if n == name || (n == "number" && name == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSpec parses the embedded document and checks that every reference in
+// it resolves.
+func loadSpec() (*apiSpec, error) {
+	var spec apiSpec
+	if err := json.Unmarshal(openAPIDocument, &spec); err != nil {
+		return nil, fmt.Errorf("parse openapi.json: %w", err)
+	}
+	for path, item := range spec.Paths {
+		for _, op := range append([]*operation{{Parameters: item.Parameters}}, item.operations()...) {
+			for _, p := range op.Parameters {
+				rp, err := spec.param(p)
+				if err != nil {
+					return nil, fmt.Errorf("openapi.json %s: %w", path, err)
+				}
+				if err := spec.checkRefs(rp.Schema); err != nil {
+					return nil, fmt.Errorf("openapi.json %s: %w", path, err)
+				}
+			}
+			if op.RequestBody == nil {
+				continue
+			}
+			for _, c := range op.RequestBody.Content {
+				if err := spec.checkRefs(c.Schema); err != nil {
+					return nil, fmt.Errorf("openapi.json %s: %w", path, err)
+				}
+			}
+		}
+	}
+	return &spec, nil
+}
+
+// operations returns the item's operations that are set.
+func (p *pathItem) operations() []*operation {
+	var ops []*operation
+	for _, op := range []*operation{p.Get, p.Put, p.Post, p.Patch, p.Delete} {
+		if op != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+// operation returns the item's operation for method, or nil.
+func (p *pathItem) operation(method string) *operation {
+	switch method {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPost:
+		return p.Post
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodDelete:
+		return p.Delete
+	}
+	return nil
+}
+
+// param resolves a parameter reference.
+func (s *apiSpec) param(p *parameter) (*parameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	rp, ok := s.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+	if !ok {
+		return nil, fmt.Errorf("unresolved reference %s", p.Ref)
+	}
+	return rp, nil
+}
+
+// schema resolves a schema reference. loadSpec has checked that it exists.
+func (s *apiSpec) schema(sc *schema) *schema {
+	for sc != nil && sc.Ref != "" {
+		sc = s.Components.Schemas[strings.TrimPrefix(sc.Ref, "#/components/schemas/")]
+	}
+	return sc
+}
+
+func (s *apiSpec) checkRefs(sc *schema) error {
+	if sc == nil {
+		return nil
+	}
+	if sc.Ref != "" {
+		name := strings.TrimPrefix(sc.Ref, "#/components/schemas/")
+		target, ok := s.Components.Schemas[name]
+		if !ok {
+			return fmt.Errorf("unresolved reference %s", sc.Ref)
+		}
+		return s.checkRefs(target)
+	}
+	for _, p := range sc.Properties {
+		if err := s.checkRefs(p); err != nil {
+			return err
+		}
+	}
+	for _, a := range sc.AllOf {
+		if err := s.checkRefs(a); err != nil {
+			return err
+		}
+	}
+	return s.checkRefs(sc.Items)
+}
+
+func (h *Handler) getOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPIDocument)
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"jiraiya/internal/service"
+)
+
+// stubService answers the calls the validation tests let through. Any other
+// method panics on the nil embedded interface.
+type stubService struct {
+	service.Service
+	submitted int
+	diff      service.DiffOptions
+}
+
+// Authenticate accepts a token named after each role, e.g. "admin-token".
+func (s *stubService) Authenticate(ctx context.Context, token string) (*service.Identity, error) {
+	role, ok := strings.CutSuffix(token, "-token")
+	if !ok || !service.ValidRole(role) {
+		return nil, service.ErrUnauthorized
+	}
+	return &service.Identity{Name: role, Role: role}, nil
+}
+
+func (s *stubService) SubmitRelease(ctx context.Context, sub service.ReleaseSubmission, opts service.WriteOptions) (*service.SubmitResult, error) {
+	s.submitted++
+	return &service.SubmitResult{ETag: `"1"`}, nil
+}
+
+func (s *stubService) GetVersions(ctx context.Context, platform string, vq service.VersionQuery) (*service.VersionList, error) {
+	return &service.VersionList{}, nil
+}
+
+func (s *stubService) GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string, opts service.DiffOptions) ([]service.JiraOutput, error) {
+	s.diff = opts
+	return []service.JiraOutput{}, nil
+}
+
+func (s *stubService) GetFeed(ctx context.Context, platform string, fq service.FeedQuery) (*service.Feed, error) {
+	return &service.Feed{Platform: platform}, nil
+}
+
+func TestSpecCoversRoutes(t *testing.T) {
+	spec, err := loadSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	routes := New(&stubService{}, slog.New(slog.NewTextHandler(io.Discard, nil))).Routes()
+
+	err = chi.Walk(routes.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		item, ok := spec.Paths[route]
+		if !ok {
+			t.Errorf("%s %s is missing from openapi.json", method, route)
+			return nil
+		}
+		if item.operation(method) == nil {
+			t.Errorf("%s %s has no %s operation in openapi.json", method, route, strings.ToLower(method))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateRequests(t *testing.T) {
+	svc := &stubService{}
+	srv := httptest.NewServer(New(svc, slog.New(slog.NewTextHandler(io.Discard, nil))).Routes())
+	t.Cleanup(srv.Close)
+
+	send := func(t *testing.T, method, path, body string) (int, map[string]any) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Authorization", "Bearer admin-token")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var got map[string]any
+		json.NewDecoder(resp.Body).Decode(&got)
+		return resp.StatusCode, got
+	}
+
+	t.Run("serves the document", func(t *testing.T) {
+		code, got := send(t, http.MethodGet, "/api/openapi.json", "")
+		if code != http.StatusOK || got["openapi"] != "3.1.0" {
+			t.Fatalf("expected the document, got %d %v", code, got)
+		}
+	})
+
+	t.Run("query params", func(t *testing.T) {
+		for path, want := range map[string]string{
+			"/api/versions":                              "platform query param is required",
+			"/api/versions?platform=ios&limit=x":         "limit must be a non-negative integer",
+			"/api/versions?platform=ios&order=up":        "order must be one of asc, desc",
+			"/api/versions?platform=ios&since=yesterday": "since must be a YYYY-MM-DD date",
+			"/api/jiras?from=1&to=2&ga_only=maybe":       "ga_only must be true or false",
+		} {
+			code, got := send(t, http.MethodGet, path, "")
+			if code != http.StatusBadRequest || got["error"] != want {
+				t.Errorf("%s: expected 400 %q, got %d %v", path, want, code, got)
+			}
+		}
+		if code, got := send(t, http.MethodGet, "/api/versions?platform=ios&limit=5&order=desc", ""); code != http.StatusOK {
+			t.Errorf("expected valid params to pass, got %d %v", code, got)
+		}
+	})
+
+	t.Run("boolean query params", func(t *testing.T) {
+		for v, want := range map[string]bool{"1": true, "TRUE": true, "0": false, "false": false} {
+			svc.diff = service.DiffOptions{GAOnly: !want}
+			if code, got := send(t, http.MethodGet, "/api/jiras?from=1&to=2&ga_only="+v, ""); code != http.StatusOK {
+				t.Fatalf("ga_only=%s: expected 200, got %d %v", v, code, got)
+			}
+			if svc.diff.GAOnly != want {
+				t.Errorf("ga_only=%s: expected GAOnly %v", v, want)
+			}
+		}
+	})
+
+	t.Run("path params", func(t *testing.T) {
+		code, got := send(t, http.MethodGet, "/api/webhooks/abc", "")
+		if code != http.StatusBadRequest || got["error"] != "id must be a positive integer" {
+			t.Fatalf("expected 400, got %d %v", code, got)
+		}
+	})
+
+	t.Run("submission body", func(t *testing.T) {
+		long := strings.Repeat("x", 1025)
+		tests := []struct {
+			name, body string
+			reasons    []string
+		}{
+			{"unknown top-level field", `{"release":{"version":"1","platform":"ios"},"extra":1}`,
+				[]string{`unknown field "extra"`}},
+			{"unknown jira field", `{"release":{"version":"1","platform":"ios"},"changes":[{"id":"A-1"},{"id":"A-2","titel":"x"}]}`,
+				[]string{`changes[1]: unknown field "titel"`}},
+			{"missing release", `{"changes":[]}`,
+				[]string{"release is required"}},
+			{"wrong type", `{"release":{"version":1,"platform":"ios"}}`,
+				[]string{"release.version: must be of type string"}},
+			{"bad status", `{"release":{"version":"1","platform":"ios","status":"beta"}}`,
+				[]string{"release.status: must be one of draft, rc, ga, withdrawn"}},
+			{"title too long", `{"release":{"version":"1","platform":"ios"},"changes":[{"id":"A-1","title":"` + long + `"}]}`,
+				[]string{"changes[0].title: must be at most 1024 characters"}},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				code, got := send(t, http.MethodPut, "/api/releases", tc.body)
+				if code != http.StatusBadRequest || got["error"] != "validation failed" {
+					t.Fatalf("expected validation failed, got %d %v", code, got)
+				}
+				details, _ := got["details"].([]any)
+				if len(details) != len(tc.reasons) {
+					t.Fatalf("expected %d details, got %v", len(tc.reasons), details)
+				}
+				for i, d := range details {
+					if reason := d.(map[string]any)["reason"]; reason != tc.reasons[i] {
+						t.Errorf("expected %q, got %q", tc.reasons[i], reason)
+					}
+				}
+			})
+		}
+
+		_, got := send(t, http.MethodPut, "/api/releases", `{"release":{"version":"1","platform":"ios"},"changes":[{"id":"A-1"},{"id":"A-2","titel":"x"}]}`)
+		if d := got["details"].([]any)[0].(map[string]any); d["index"] != 1.0 || d["id"] != "A-2" {
+			t.Errorf("expected the detail to name changes[1] A-2, got %v", d)
+		}
+		if svc.submitted != 0 {
+			t.Fatalf("expected invalid submissions to stop before the service, got %d calls", svc.submitted)
+		}
+	})
+
+	t.Run("body limits", func(t *testing.T) {
+		changes := strings.Repeat(`{"id":"A-1"},`, 10001)
+		body := `{"release":{"version":"1","platform":"ios"},"changes":[` + strings.TrimSuffix(changes, ",") + `]}`
+		code, got := send(t, http.MethodPut, "/api/releases", body)
+		if code != http.StatusBadRequest || got["details"].([]any)[0].(map[string]any)["reason"] != "changes: must have at most 10000 items" {
+			t.Fatalf("expected too many changes, got %d %v", code, got["error"])
+		}
+
+		code, _ = send(t, http.MethodPut, "/api/releases", `{"release":{"version":"1","relnotes":"`+strings.Repeat("x", maxBodyBytes)+`"}}`)
+		if code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("expected 413, got %d", code)
+		}
+	})
+
+	t.Run("valid submission reaches the service", func(t *testing.T) {
+		code, got := send(t, http.MethodPut, "/api/releases",
+			`{"release":{"version":"1","platform":"ios","release_date":"2026-01-01"},"changes":[{"id":"A-1","title":"t","relnotes":"r"}]}`)
+		if code != http.StatusOK || svc.submitted != 1 {
+			t.Fatalf("expected the submission to pass, got %d %v", code, got)
+		}
+	})
+}
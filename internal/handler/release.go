@@ -2,7 +2,6 @@ package handler
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -16,13 +15,9 @@ func (h *Handler) submitRelease(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.svc.SubmitRelease(r.Context(), sub); err != nil {
-		var ve *service.ValidationError
-		if errors.As(err, &ve) {
-			writeJSON(w, http.StatusBadRequest, map[string]any{
-				"error":   "validation failed",
-				"details": ve.Details,
-			})
+	res, err := h.svc.SubmitRelease(r.Context(), sub, writeOptions(r))
+	if err != nil {
+		if writeServiceError(w, err) {
 			return
 		}
 		h.log.Error("submit release failed", "error", err)
@@ -30,7 +25,10 @@ func (h *Handler) submitRelease(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	if res.Replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+	writeEditResult(w, res)
 }
 
 func (h *Handler) getReleases(w http.ResponseWriter, r *http.Request) {
@@ -41,16 +39,77 @@ func (h *Handler) getReleases(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	format, err := exportFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if version == "" {
+		h.listReleases(w, r, platform, format)
+		return
+	}
+
 	releases, err := h.svc.GetReleases(r.Context(), version, platform)
 	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
 		h.log.Error("get releases failed", "version", version, "platform", platform, "error", err)
 		writeError(w, http.StatusInternalServerError, "internal error")
 		return
 	}
 
+	if len(releases) == 1 {
+		w.Header().Set("ETag", releases[0].ETag)
+	}
+
+	if format != formatJSON {
+		if err := exportRows(w, format, releaseColumns, releases, releaseRecord); err != nil {
+			h.log.Error("export releases failed", "version", version, "error", err)
+		}
+		return
+	}
 	writeJSON(w, http.StatusOK, releases)
 }
 
+func (h *Handler) listReleases(w http.ResponseWriter, r *http.Request, platform, format string) {
+	lq, err := listQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if format != formatJSON {
+		// Exports stream the whole list in the requested order.
+		lq.Limit = exportPageSize
+		started, err := exportPages(w, format, releaseColumns, releaseRecord, func(cursor string) ([]service.ReleaseOutput, string, error) {
+			lq.Cursor = cursor
+			list, err := h.svc.ListReleases(r.Context(), platform, lq)
+			if err != nil {
+				return nil, "", err
+			}
+			return list.Releases, list.NextCursor, nil
+		})
+		if err != nil {
+			h.exportFailed(w, started, err, "export releases failed", "platform", platform)
+		}
+		return
+	}
+
+	list, err := h.svc.ListReleases(r.Context(), platform, lq)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("list releases failed", "platform", platform, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writePage(w, r, list.Releases, list.Total, list.NextCursor)
+}
+
 func (h *Handler) deleteRelease(w http.ResponseWriter, r *http.Request) {
 	version := chi.URLParam(r, "version")
 	if version == "" {
@@ -58,7 +117,10 @@ func (h *Handler) deleteRelease(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.svc.DeleteRelease(r.Context(), version); err != nil {
+	if err := h.svc.DeleteRelease(r.Context(), version, writeOptions(r)); err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
 		h.log.Error("delete release failed", "version", version, "error", err)
 		writeError(w, http.StatusInternalServerError, "internal error")
 		return
@@ -66,3 +128,197 @@ func (h *Handler) deleteRelease(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
+
+func (h *Handler) getReleaseHistory(w http.ResponseWriter, r *http.Request) {
+	version := chi.URLParam(r, "version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	history, err := h.svc.GetReleaseHistory(r.Context(), version)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("get release history failed", "version", version, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+}
+
+func (h *Handler) transitionRelease(w http.ResponseWriter, r *http.Request) {
+	version := chi.URLParam(r, "version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	rel, err := h.svc.TransitionRelease(r.Context(), version, body.Status, writeOptions(r))
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("transition release failed", "version", version, "status", body.Status, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("ETag", rel.ETag)
+	writeJSON(w, http.StatusOK, rel)
+}
+
+func (h *Handler) lockRelease(w http.ResponseWriter, r *http.Request) {
+	h.setReleaseLock(w, r, true)
+}
+
+func (h *Handler) unlockRelease(w http.ResponseWriter, r *http.Request) {
+	h.setReleaseLock(w, r, false)
+}
+
+func (h *Handler) setReleaseLock(w http.ResponseWriter, r *http.Request, locked bool) {
+	version := chi.URLParam(r, "version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	rel, err := h.svc.SetReleaseLock(r.Context(), version, locked, writeOptions(r))
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("set release lock failed", "version", version, "locked", locked, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("ETag", rel.ETag)
+	writeJSON(w, http.StatusOK, rel)
+}
+
+func (h *Handler) restoreRelease(w http.ResponseWriter, r *http.Request) {
+	version := chi.URLParam(r, "version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	rel, err := h.svc.RestoreRelease(r.Context(), version, writeOptions(r))
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("restore release failed", "version", version, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("ETag", rel.ETag)
+	writeJSON(w, http.StatusOK, rel)
+}
+
+func (h *Handler) updateRelease(w http.ResponseWriter, r *http.Request) {
+	version := chi.URLParam(r, "version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	var patch service.ReleasePatch
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	rel, err := h.svc.UpdateRelease(r.Context(), version, patch, writeOptions(r))
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("update release failed", "version", version, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("ETag", rel.ETag)
+	writeJSON(w, http.StatusOK, rel)
+}
+
+func (h *Handler) addReleaseJira(w http.ResponseWriter, r *http.Request) {
+	version := chi.URLParam(r, "version")
+	if version == "" {
+		writeError(w, http.StatusBadRequest, "version is required")
+		return
+	}
+
+	var j service.JiraInput
+	if err := json.NewDecoder(r.Body).Decode(&j); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	res, err := h.svc.AddReleaseJira(r.Context(), version, j, writeOptions(r))
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("add release jira failed", "version", version, "jira_id", j.ID, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeEditResult(w, res)
+}
+
+func (h *Handler) removeReleaseJira(w http.ResponseWriter, r *http.Request) {
+	version := chi.URLParam(r, "version")
+	id := chi.URLParam(r, "id")
+	if version == "" || id == "" {
+		writeError(w, http.StatusBadRequest, "version and jira id are required")
+		return
+	}
+
+	res, err := h.svc.RemoveReleaseJira(r.Context(), version, id, writeOptions(r))
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("remove release jira failed", "version", version, "jira_id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeEditResult(w, res)
+}
+
+// writeEditResult writes the response for a successful release submission or
+// single-jira edit.
+func writeEditResult(w http.ResponseWriter, res *service.SubmitResult) {
+	w.Header().Set("ETag", res.ETag)
+	if len(res.Warnings) > 0 {
+		writeJSON(w, http.StatusOK, map[string]any{"status": "ok", "warnings": res.Warnings})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// writeOptions extracts write preconditions and the admin override reason
+// from the request headers.
+func writeOptions(r *http.Request) service.WriteOptions {
+	return service.WriteOptions{
+		IfMatch:        r.Header.Get("If-Match"),
+		IdempotencyKey: r.Header.Get("Idempotency-Key"),
+		Override:       r.Header.Get("X-Admin-Override"),
+	}
+}
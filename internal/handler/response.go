@@ -2,7 +2,10 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"jiraiya/internal/service"
 )
 
 func writeJSON(w http.ResponseWriter, status int, v any) {
@@ -14,3 +17,35 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 func writeError(w http.ResponseWriter, status int, msg string) {
 	writeJSON(w, status, map[string]string{"error": msg})
 }
+
+// writeServiceError writes the response for errors the service reports to
+// callers on purpose. It returns false for any other error, which the caller
+// should log and report as an internal error.
+func writeServiceError(w http.ResponseWriter, err error) bool {
+	var ve *service.ValidationError
+	var te *service.TransitionError
+	switch {
+	case errors.As(err, &ve):
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"error":   "validation failed",
+			"details": ve.Details,
+		})
+	case errors.As(err, &te):
+		writeError(w, http.StatusConflict, te.Error())
+	case errors.Is(err, service.ErrNotFound):
+		writeError(w, http.StatusNotFound, "not found")
+	case errors.Is(err, service.ErrPreconditionFailed):
+		writeError(w, http.StatusPreconditionFailed, "release was modified (If-Match mismatch)")
+	case errors.Is(err, service.ErrIdempotencyConflict):
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, service.ErrReleaseLocked):
+		writeError(w, http.StatusLocked, "release is locked; set X-Admin-Override with a reason to change it")
+	case errors.Is(err, service.ErrUnauthorized):
+		writeUnauthorized(w, "unauthorized")
+	case errors.Is(err, service.ErrForbidden):
+		writeError(w, http.StatusForbidden, "forbidden")
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"jiraiya/internal/service"
+)
+
+func (h *Handler) createToken(w http.ResponseWriter, r *http.Request) {
+	var in service.TokenInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	tok, err := h.svc.CreateToken(r.Context(), in)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("create token failed", "name", in.Name, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, tok)
+}
+
+func (h *Handler) listTokens(w http.ResponseWriter, r *http.Request) {
+	toks, err := h.svc.ListTokens(r.Context())
+	if err != nil {
+		h.log.Error("list tokens failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toks)
+}
+
+func (h *Handler) revokeToken(w http.ResponseWriter, r *http.Request) {
+	id, ok := idParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RevokeToken(r.Context(), id); err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("revoke token failed", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
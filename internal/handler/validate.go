@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"jiraiya/internal/service"
+)
+
+// maxBodyBytes caps request bodies. Larger ones are rejected with 413 before
+// they are parsed.
+const maxBodyBytes = 8 << 20
+
+// validateRequests checks each request against the operation the spec gives
+// for its route before it reaches the handler. Query, path and header params
+// that break their schema get a 400 naming the param; bodies that break
+// theirs get the same "validation failed" response as service validation
+// errors, with one detail per problem. Routes the spec does not describe are
+// passed through unchecked.
+func validateRequests(spec *apiSpec, mux *chi.Mux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path := r.URL.RawPath
+			if path == "" {
+				path = r.URL.Path
+			}
+			rctx := chi.NewRouteContext()
+			item := spec.Paths[mux.Find(rctx, r.Method, path)]
+			if item == nil || item.operation(r.Method) == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			op := item.operation(r.Method)
+
+			query := r.URL.Query()
+			for _, p := range slices.Concat(item.Parameters, op.Parameters) {
+				p, err := spec.param(p)
+				if err == nil {
+					err = checkParam(spec, p, r, query, rctx)
+				}
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+			}
+
+			if op.RequestBody != nil && !checkBody(w, r, spec, op) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// checkParam checks a single query, path or header param.
+func checkParam(spec *apiSpec, p *parameter, r *http.Request, query url.Values, rctx *chi.Context) error {
+	var value string
+	switch p.In {
+	case "query":
+		value = query.Get(p.Name)
+	case "path":
+		value = rctx.URLParam(p.Name)
+		if r.URL.RawPath != "" {
+			if v, err := url.PathUnescape(value); err == nil {
+				value = v
+			}
+		}
+	case "header":
+		value = r.Header.Get(p.Name)
+	}
+	// Handlers treat an empty param as an absent one.
+	if value == "" {
+		if p.Required {
+			return fmt.Errorf("%s %s param is required", p.Name, p.In)
+		}
+		return nil
+	}
+	if reason := valueError(spec.schema(p.Schema), value); reason != "" {
+		return fmt.Errorf("%s %s", p.Name, reason)
+	}
+	return nil
+}
+
+// valueError checks a param's raw string value against its schema and
+// returns why it fails, or "".
+func valueError(sc *schema, v string) string {
+	if sc == nil {
+		return ""
+	}
+	switch {
+	case len(sc.Type) == 1 && sc.Type[0] == "integer":
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || (sc.Minimum != nil && float64(n) < *sc.Minimum) {
+			return integerReason(sc)
+		}
+	case len(sc.Type) == 1 && sc.Type[0] == "boolean":
+		if _, err := strconv.ParseBool(v); err != nil {
+			return "must be true or false"
+		}
+	default:
+		return stringError(sc, v)
+	}
+	return ""
+}
+
+func integerReason(sc *schema) string {
+	switch {
+	case sc.Minimum == nil:
+		return "must be an integer"
+	case *sc.Minimum == 0:
+		return "must be a non-negative integer"
+	case *sc.Minimum == 1:
+		return "must be a positive integer"
+	}
+	return fmt.Sprintf("must be an integer of at least %v", *sc.Minimum)
+}
+
+func stringError(sc *schema, v string) string {
+	if len(sc.Enum) > 0 && !slices.Contains(sc.Enum, v) {
+		return "must be one of " + strings.Join(sc.Enum, ", ")
+	}
+	if sc.Format == "date" {
+		if _, err := time.Parse(service.DateLayout, v); err != nil {
+			return "must be a YYYY-MM-DD date"
+		}
+	}
+	if sc.MaxLength != nil && utf8.RuneCountInString(v) > *sc.MaxLength {
+		return fmt.Sprintf("must be at most %d characters", *sc.MaxLength)
+	}
+	return ""
+}
+
+// checkBody reads the request body, checks it against the operation's JSON
+// schema and puts it back for the handler. It writes the error response and
+// returns false if the body is rejected.
+func checkBody(w http.ResponseWriter, r *http.Request, spec *apiSpec, op *operation) bool {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body too large; the limit is %d bytes", maxBodyBytes))
+		} else {
+			writeError(w, http.StatusBadRequest, "read body: "+err.Error())
+		}
+		return false
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	if len(data) == 0 && !op.RequestBody.Required {
+		return true
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return false
+	}
+
+	c := bodyChecker{spec: spec}
+	c.check(op.RequestBody.Content["application/json"].Schema, doc, "", service.ValidationDetail{})
+	if len(c.details) > 0 {
+		writeServiceError(w, &service.ValidationError{Details: c.details})
+		return false
+	}
+	return true
+}
+
+// bodyChecker collects the ways a decoded JSON body breaks its schema.
+// Problems inside an array element carry the element's index and, if it has
+// one, its id, the way service validation reports bad jiras.
+type bodyChecker struct {
+	spec    *apiSpec
+	details []service.ValidationDetail
+}
+
+func (c *bodyChecker) check(sc *schema, v any, path string, at service.ValidationDetail) {
+	sc = c.spec.schema(sc)
+	if sc == nil {
+		return
+	}
+	fail := func(reason string) {
+		d := at
+		d.Reason = reason
+		if path != "" {
+			d.Reason = path + ": " + reason
+		}
+		c.details = append(c.details, d)
+	}
+
+	for _, sub := range sc.AllOf {
+		c.check(sub, v, path, at)
+	}
+	if !sc.Type.has(jsonType(v)) {
+		fail("must be of type " + strings.Join(sc.Type, " or "))
+		return
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, name := range sc.Required {
+			if _, ok := v[name]; !ok {
+				fail(name + " is required")
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			prop, ok := sc.Properties[name]
+			if !ok {
+				if sc.AdditionalProperties != nil && !*sc.AdditionalProperties {
+					fail(fmt.Sprintf("unknown field %q", name))
+				}
+				continue
+			}
+			c.check(prop, v[name], joinPath(path, name), at)
+		}
+	case []any:
+		if sc.MaxItems != nil && len(v) > *sc.MaxItems {
+			fail(fmt.Sprintf("must have at most %d items", *sc.MaxItems))
+			return
+		}
+		for i, item := range v {
+			el := service.ValidationDetail{Index: i}
+			if m, ok := item.(map[string]any); ok {
+				el.ID, _ = m["id"].(string)
+			}
+			c.check(sc.Items, item, fmt.Sprintf("%s[%d]", path, i), el)
+		}
+	case string:
+		if reason := stringError(sc, v); reason != "" {
+			fail(reason)
+		}
+	case json.Number:
+		if n, err := v.Float64(); err == nil && sc.Minimum != nil && n < *sc.Minimum {
+			fail(fmt.Sprintf("must be at least %v", *sc.Minimum))
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonType names the JSON Schema type of a value decoded with UseNumber.
+func jsonType(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	}
+	return "object"
+}
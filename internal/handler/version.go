@@ -1,7 +1,12 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
+
+	"jiraiya/internal/service"
 )
 
 func (h *Handler) getVersions(w http.ResponseWriter, r *http.Request) {
@@ -11,12 +16,70 @@ func (h *Handler) getVersions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	versions, err := h.svc.GetVersions(r.Context(), platform)
+	var vq service.VersionQuery
+	var err error
+	if vq.Since, err = dateParam(r, "since"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if vq.Until, err = dateParam(r, "until"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if v := r.URL.Query().Get("status"); v != "" {
+		vq.Statuses = strings.Split(v, ",")
+	}
+	if vq.ListQuery, err = listQuery(r); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	format, err := exportFormat(r)
 	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if format != formatJSON {
+		// Exports stream the whole list in the requested order.
+		vq.Limit = exportPageSize
+		started, err := exportPages(w, format, versionColumns, versionRecord, func(cursor string) ([]service.VersionInfo, string, error) {
+			vq.Cursor = cursor
+			list, err := h.svc.GetVersions(r.Context(), platform, vq)
+			if err != nil {
+				return nil, "", err
+			}
+			return list.Versions, list.NextCursor, nil
+		})
+		if err != nil {
+			h.exportFailed(w, started, err, "export versions failed", "platform", platform)
+		}
+		return
+	}
+
+	list, err := h.svc.GetVersions(r.Context(), platform, vq)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
 		h.log.Error("get versions failed", "platform", platform, "error", err)
 		writeError(w, http.StatusInternalServerError, "internal error")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, versions)
+	writePage(w, r, list.Versions, list.Total, list.NextCursor)
+}
+
+// dateParam parses an optional YYYY-MM-DD query param. A missing param yields
+// the zero time.
+func dateParam(r *http.Request, name string) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(service.DateLayout, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a YYYY-MM-DD date", name)
+	}
+	return t, nil
 }
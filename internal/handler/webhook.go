@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"jiraiya/internal/service"
+)
+
+func (h *Handler) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var in service.WebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid json: "+err.Error())
+		return
+	}
+
+	hook, err := h.svc.CreateWebhook(r.Context(), in)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("create webhook failed", "platform", in.Platform, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, hook)
+}
+
+func (h *Handler) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	platform := r.URL.Query().Get("platform")
+
+	hooks, err := h.svc.ListWebhooks(r.Context(), platform)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("list webhooks failed", "platform", platform, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, hooks)
+}
+
+func (h *Handler) getWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := idParam(w, r)
+	if !ok {
+		return
+	}
+
+	hook, err := h.svc.GetWebhook(r.Context(), id)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("get webhook failed", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, hook)
+}
+
+func (h *Handler) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := idParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteWebhook(r.Context(), id); err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("delete webhook failed", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// listWebhookDeliveries serves the delivery log of a single subscription.
+func (h *Handler) listWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := idParam(w, r)
+	if !ok {
+		return
+	}
+	dq := service.DeliveryQuery{SubscriptionID: id, Status: r.URL.Query().Get("status")}
+	h.writeDeliveries(w, r, dq)
+}
+
+// listDeadLetters serves the deliveries that ran out of attempts.
+func (h *Handler) listDeadLetters(w http.ResponseWriter, r *http.Request) {
+	dq := service.DeliveryQuery{Platform: r.URL.Query().Get("platform"), Status: service.DeliveryDead}
+	h.writeDeliveries(w, r, dq)
+}
+
+func (h *Handler) writeDeliveries(w http.ResponseWriter, r *http.Request, dq service.DeliveryQuery) {
+	var err error
+	if dq.Limit, err = intParam(r, "limit"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if dq.Offset, err = intParam(r, "offset"); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deliveries, err := h.svc.ListWebhookDeliveries(r.Context(), dq)
+	if err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("list webhook deliveries failed", "subscription_id", dq.SubscriptionID, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+func (h *Handler) retryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	id, ok := idParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RetryWebhookDelivery(r.Context(), id); err != nil {
+		if writeServiceError(w, err) {
+			return
+		}
+		h.log.Error("retry webhook delivery failed", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": service.DeliveryPending})
+}
+
+// idParam parses the numeric {id} URL param, writing a 400 if it is invalid.
+func idParam(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id <= 0 {
+		writeError(w, http.StatusBadRequest, "id must be a positive integer")
+		return 0, false
+	}
+	return id, true
+}
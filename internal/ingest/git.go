@@ -0,0 +1,95 @@
+// Package ingest derives release contents from external sources such as git
+// history.
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// DefaultJiraPattern matches Jira issue keys such as ABC-123.
+const DefaultJiraPattern = `\b[A-Z][A-Z0-9_]+-[0-9]+\b`
+
+// GitRange selects the commits of a release: those reachable from To but not
+// from From. An empty From selects all history up to To.
+type GitRange struct {
+	// Repo is the path of a local repository, bare or with a work tree.
+	Repo string
+	From string
+	To   string
+}
+
+// GitJiraIDs returns the jira keys that pattern finds in the commit messages
+// of r, de-duplicated and in the order they first appear, oldest commit
+// first. It only reads the local repository and never touches the network.
+func GitJiraIDs(ctx context.Context, r GitRange, pattern *regexp.Regexp) ([]string, error) {
+	if r.To == "" {
+		return nil, errors.New("to ref is required")
+	}
+	to, err := resolveCommit(ctx, r.Repo, r.To)
+	if err != nil {
+		return nil, err
+	}
+	rev := to
+	if r.From != "" {
+		from, err := resolveCommit(ctx, r.Repo, r.From)
+		if err != nil {
+			return nil, err
+		}
+		rev = from + ".." + to
+	}
+
+	// Each message is terminated by a NUL so multi-line bodies stay intact.
+	out, err := git(ctx, r.Repo, "log", "--reverse", "--format=%B%x00", rev)
+	if err != nil {
+		return nil, err
+	}
+	messages := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	return extractJiraIDs(messages, pattern), nil
+}
+
+func resolveCommit(ctx context.Context, repo, ref string) (string, error) {
+	if strings.HasPrefix(ref, "-") {
+		return "", fmt.Errorf("invalid ref %q", ref)
+	}
+	out, err := git(ctx, repo, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unknown ref %q in %s", ref, repo)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func git(ctx context.Context, repo string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", repo}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// extractJiraIDs returns every match of pattern across messages, keeping the
+// first occurrence of each.
+func extractJiraIDs(messages []string, pattern *regexp.Regexp) []string {
+	seen := make(map[string]bool)
+	ids := []string{}
+	for _, m := range messages {
+		for _, id := range pattern.FindAllString(m, -1) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,86 @@
+package ingest
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// newBareRepo builds a repository with two tagged releases and returns the
+// path of a bare clone of it:
+//
+//	v1.0: "ABC-1 initial", "Fix ABC-2 and XYZ-10"
+//	v1.1: "ABC-2 follow-up\n\nAlso ABC-3", "chore: no key", "ABC-4"
+func newBareRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work")
+	bare := filepath.Join(dir, "repo.git")
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(cmd.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(msg string) {
+		t.Helper()
+		run("-C", work, "-c", "user.name=test", "-c", "user.email=test@example.com",
+			"commit", "--allow-empty", "-q", "-m", msg)
+	}
+
+	run("init", "-q", work)
+	commit("ABC-1 initial")
+	commit("Fix ABC-2 and XYZ-10")
+	run("-C", work, "tag", "v1.0")
+	commit("ABC-2 follow-up\n\nAlso ABC-3")
+	commit("chore: no key")
+	commit("ABC-4")
+	run("-C", work, "tag", "v1.1")
+	run("clone", "-q", "--bare", work, bare)
+	return bare
+}
+
+func TestGitJiraIDs(t *testing.T) {
+	repo := newBareRepo(t)
+	pattern := regexp.MustCompile(DefaultJiraPattern)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		from, to string
+		pattern  *regexp.Regexp
+		want     []string
+	}{
+		{"between tags", "v1.0", "v1.1", pattern, []string{"ABC-2", "ABC-3", "ABC-4"}},
+		{"first release", "", "v1.0", pattern, []string{"ABC-1", "ABC-2", "XYZ-10"}},
+		{"custom pattern", "", "v1.1", regexp.MustCompile(`XYZ-\d+`), []string{"XYZ-10"}},
+		{"empty range", "v1.1", "v1.1", pattern, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GitJiraIDs(ctx, GitRange{Repo: repo, From: tt.from, To: tt.to}, tt.pattern)
+			if err != nil {
+				t.Fatalf("GitJiraIDs failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := GitJiraIDs(ctx, GitRange{Repo: repo, From: "v0.9", To: "v1.1"}, pattern); err == nil {
+		t.Error("expected an error for an unknown ref")
+	}
+	if _, err := GitJiraIDs(ctx, GitRange{Repo: repo, To: "--all"}, pattern); err == nil {
+		t.Error("expected an error for a ref that looks like a flag")
+	}
+}
@@ -0,0 +1,257 @@
+// Package jiraapi is a minimal client for the Jira REST API, used to fill in
+// jira metadata that release submissions leave empty.
+package jiraapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNotFound is returned when Jira has no issue with the requested key.
+var ErrNotFound = errors.New("jira issue not found")
+
+// RateLimitError is returned when Jira answers 429. The client holds back
+// further requests until RetryAfter has passed.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("jira rate limit exceeded, retry after %s", e.RetryAfter)
+}
+
+// defaultRetryAfter is used when a 429 carries no usable Retry-After header.
+const defaultRetryAfter = time.Minute
+
+// FieldMap names the Jira field each jira attribute is read from, e.g.
+// "summary", "priority" or "customfield_10042". Empty entries are not fetched.
+type FieldMap struct {
+	Title    string
+	Domain   string
+	Impact   string
+	Relnotes string
+}
+
+// DefaultFieldMap maps the attributes onto stock Jira fields. Relnotes has no
+// stock equivalent and is left unmapped.
+func DefaultFieldMap() FieldMap {
+	return FieldMap{Title: "summary", Domain: "components", Impact: "priority"}
+}
+
+func (m FieldMap) names() []string {
+	var out []string
+	for _, f := range []string{m.Title, m.Domain, m.Impact, m.Relnotes} {
+		if f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Config configures a Client. Only BaseURL is required.
+type Config struct {
+	// BaseURL is the Jira site, e.g. https://example.atlassian.net.
+	BaseURL string
+	// User and Token authenticate with basic auth. A Token without a User
+	// is sent as a bearer token.
+	User  string
+	Token string
+	// Fields maps jira attributes onto Jira fields. The zero value uses
+	// DefaultFieldMap.
+	Fields FieldMap
+	// RequestsPerSecond spaces out requests. Zero means no limit.
+	RequestsPerSecond float64
+	// Timeout bounds a single request. Zero uses defaultTimeout.
+	Timeout time.Duration
+}
+
+// defaultTimeout is used when Config.Timeout is zero.
+const defaultTimeout = 10 * time.Second
+
+// Issue is the jira metadata read from a Jira issue.
+type Issue struct {
+	Key      string
+	Title    string
+	Domain   string
+	Impact   string
+	Relnotes string
+}
+
+// Client fetches issues from Jira. It is safe for concurrent use.
+type Client struct {
+	cfg      Config
+	http     *http.Client
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// New creates a Client.
+func New(cfg Config) *Client {
+	if cfg.Fields == (FieldMap{}) {
+		cfg.Fields = DefaultFieldMap()
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	c := &Client{cfg: cfg, http: &http.Client{Timeout: timeout}}
+	if cfg.RequestsPerSecond > 0 {
+		c.interval = time.Duration(float64(time.Second) / cfg.RequestsPerSecond)
+	}
+	return c
+}
+
+// Fields returns the field mapping in use.
+func (c *Client) Fields() FieldMap {
+	return c.cfg.Fields
+}
+
+// Issue fetches the mapped fields of the issue with the given key.
+func (c *Client) Issue(ctx context.Context, key string) (*Issue, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	u := strings.TrimRight(c.cfg.BaseURL, "/") + "/rest/api/2/issue/" + url.PathEscape(key) +
+		"?fields=" + url.QueryEscape(strings.Join(c.cfg.Fields.names(), ","))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case c.cfg.User != "":
+		req.SetBasicAuth(c.cfg.User, c.cfg.Token)
+	case c.cfg.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get issue %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		retry := retryAfter(resp.Header.Get("Retry-After"))
+		c.holdUntil(time.Now().Add(retry))
+		return nil, &RateLimitError{RetryAfter: retry}
+	case resp.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return nil, fmt.Errorf("get issue %s: unexpected status %d", key, resp.StatusCode)
+	}
+
+	var body struct {
+		Key    string         `json:"key"`
+		Fields map[string]any `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode issue %s: %w", key, err)
+	}
+	f := c.cfg.Fields
+	return &Issue{
+		Key:      key,
+		Title:    fieldText(body.Fields, f.Title),
+		Domain:   fieldText(body.Fields, f.Domain),
+		Impact:   fieldText(body.Fields, f.Impact),
+		Relnotes: fieldText(body.Fields, f.Relnotes),
+	}, nil
+}
+
+// wait blocks until the rate limit allows another request.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	start := c.next
+	if start.Before(now) {
+		start = now
+	}
+	c.next = start.Add(c.interval)
+	c.mu.Unlock()
+
+	if d := time.Until(start); d > 0 {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+	return nil
+}
+
+// holdUntil defers every further request until t.
+func (c *Client) holdUntil(t time.Time) {
+	c.mu.Lock()
+	if t.After(c.next) {
+		c.next = t
+	}
+	c.mu.Unlock()
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(v string) time.Duration {
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return defaultRetryAfter
+}
+
+// fieldText renders a Jira field value as text. Option, user and similar
+// objects yield their value or name; arrays such as components are joined
+// with commas.
+func fieldText(fields map[string]any, name string) string {
+	if name == "" {
+		return ""
+	}
+	return valueText(fields[name])
+}
+
+func valueText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, 0, len(v))
+		for _, e := range v {
+			if s := valueText(e); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]any:
+		for _, k := range []string{"value", "name", "displayName", "key"} {
+			if s, ok := v[k].(string); ok && s != "" {
+				return strings.TrimSpace(s)
+			}
+		}
+	}
+	return ""
+}
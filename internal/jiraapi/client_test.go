@@ -0,0 +1,118 @@
+package jiraapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIssueFieldMapping(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/ABC-1" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("fields"); got != "summary,components,priority,customfield_1" {
+			t.Errorf("unexpected fields param %q", got)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "bot" || pass != "tok" {
+			t.Errorf("expected basic auth, got %q %q %v", user, pass, ok)
+		}
+		w.Write([]byte(`{"key":"ABC-1","fields":{
+			"summary":" Crash on launch ",
+			"components":[{"name":"Player"},{"name":"Search"}],
+			"priority":{"name":"High","id":"2"},
+			"customfield_1":{"value":"Fixed a crash"}
+		}}`))
+	}))
+	defer srv.Close()
+
+	c := New(Config{
+		BaseURL: srv.URL + "/",
+		User:    "bot",
+		Token:   "tok",
+		Fields:  FieldMap{Title: "summary", Domain: "components", Impact: "priority", Relnotes: "customfield_1"},
+	})
+
+	issue, err := c.Issue(context.Background(), "ABC-1")
+	if err != nil {
+		t.Fatalf("Issue failed: %v", err)
+	}
+	want := Issue{Key: "ABC-1", Title: "Crash on launch", Domain: "Player, Search", Impact: "High", Relnotes: "Fixed a crash"}
+	if *issue != want {
+		t.Errorf("got %+v, want %+v", *issue, want)
+	}
+
+	if _, err := c.Issue(context.Background(), "ABC-2"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestIssueRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := New(Config{BaseURL: srv.URL})
+	_, err := c.Issue(context.Background(), "ABC-1")
+	var rle *RateLimitError
+	if !errors.As(err, &rle) {
+		t.Fatalf("expected RateLimitError, got %v", err)
+	}
+	if rle.RetryAfter != 2*time.Second {
+		t.Errorf("expected 2s retry, got %s", rle.RetryAfter)
+	}
+
+	// The next request waits out the Retry-After, so a short deadline fails.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Issue(ctx, "ABC-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the client to hold back, got %v", err)
+	}
+}
+
+func TestRequestSpacing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fields":{}}`))
+	}))
+	defer srv.Close()
+
+	c := New(Config{BaseURL: srv.URL, RequestsPerSecond: 20})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Issue(context.Background(), "ABC-1"); err != nil {
+			t.Fatalf("Issue failed: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("3 requests at 20/s took %s, expected at least 100ms", elapsed)
+	}
+}
+
+func TestValueText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"nil", nil, ""},
+		{"string", "  text ", "text"},
+		{"number", 3.0, "3"},
+		{"option", map[string]any{"value": "Major"}, "Major"},
+		{"user", map[string]any{"displayName": "Jane"}, "Jane"},
+		{"unknown object", map[string]any{"id": "1"}, ""},
+		{"labels", []any{"a", "", "b"}, "a, b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := valueText(tt.in); got != tt.want {
+				t.Errorf("valueText(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
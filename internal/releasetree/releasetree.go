@@ -128,6 +128,44 @@ func (tree *ReleaseTree) InsertNode(input ReleaseInput) error {
 	return nil
 }
 
+// AddChange appends a change to an existing node concurrently safely. Adding a
+// change the node already has is a no-op.
+func (tree *ReleaseTree) AddChange(version string, chg Chg) error {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+
+	n, exists := tree.nodes[version]
+	if !exists {
+		return fmt.Errorf("AddChange: version '%s' not found", version)
+	}
+	for _, c := range n.changes {
+		if c.ID == chg.ID {
+			return nil
+		}
+	}
+	n.changes = append(n.changes, chg)
+	return nil
+}
+
+// RemoveChange removes a change from an existing node concurrently safely.
+// Removing a change the node does not have is a no-op.
+func (tree *ReleaseTree) RemoveChange(version, id string) error {
+	tree.mu.Lock()
+	defer tree.mu.Unlock()
+
+	n, exists := tree.nodes[version]
+	if !exists {
+		return fmt.Errorf("RemoveChange: version '%s' not found", version)
+	}
+	for i, c := range n.changes {
+		if c.ID == id {
+			n.changes = append(n.changes[:i:i], n.changes[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
 // findLCA is the internal implementation without locking.
 func (tree *ReleaseTree) findLCA(version1, version2 string) (*node, error) {
 	node1, exists1 := tree.nodes[version1]
@@ -171,26 +209,67 @@ func (tree *ReleaseTree) FindLCA(version1, version2 string) (string, error) {
 	return lcaNode.version, nil
 }
 
+// AttributedChg is a change together with the version on the end path that
+// introduced it.
+type AttributedChg struct {
+	Chg
+	Version string
+}
+
 // CalcChgs calculates the net changes concurrently safely.
 func (tree *ReleaseTree) CalcChgs(endVersion, startVersion string) ([]Chg, error) {
 	tree.mu.RLock()
 	defer tree.mu.RUnlock()
 
+	attributed, err := tree.calcChgs(endVersion, startVersion, nil)
+	if err != nil {
+		return nil, fmt.Errorf("CalcChgs: %w", err)
+	}
+	result := make([]Chg, len(attributed))
+	for i, a := range attributed {
+		result[i] = a.Chg
+	}
+	return result, nil
+}
+
+// CalcChgsAttributed calculates the net changes like CalcChgs and reports the
+// version that introduced each one. If skip is non-nil, changes introduced by
+// an intermediate version for which skip returns true are attributed to the
+// nearest non-skipped descendant on the end path instead. The end version
+// itself is never skipped, so skipped nodes still shape the topology.
+func (tree *ReleaseTree) CalcChgsAttributed(endVersion, startVersion string, skip func(version string) bool) ([]AttributedChg, error) {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+
+	result, err := tree.calcChgs(endVersion, startVersion, skip)
+	if err != nil {
+		return nil, fmt.Errorf("CalcChgsAttributed: %w", err)
+	}
+	return result, nil
+}
+
+// calcChgs is the internal implementation without locking.
+func (tree *ReleaseTree) calcChgs(endVersion, startVersion string, skip func(string) bool) ([]AttributedChg, error) {
 	lcaNode, err := tree.findLCA(endVersion, startVersion)
 	if err != nil {
-		return nil, fmt.Errorf("CalcChgs: failed to find LCA for '%s' and '%s': %w", endVersion, startVersion, err)
+		return nil, fmt.Errorf("failed to find LCA for '%s' and '%s': %w", endVersion, startVersion, err)
 	}
 
 	endNode := tree.nodes[endVersion]
 	startNode := tree.nodes[startVersion]
 
-	netChanges := make(map[string]Chg)
+	netChanges := make(map[string]AttributedChg)
 
-	// Accumulate End Path Changes
+	// Accumulate End Path Changes. Walking upwards, an ancestor overwrites a
+	// descendant, so each change ends up attributed to its earliest release.
+	attr := endNode.version
 	curr := endNode
 	for curr != nil && curr != lcaNode {
+		if curr == endNode || skip == nil || !skip(curr.version) {
+			attr = curr.version
+		}
 		for _, change := range curr.changes {
-			netChanges[change.ID] = change
+			netChanges[change.ID] = AttributedChg{Chg: change, Version: attr}
 		}
 		curr = curr.parent
 	}
@@ -200,7 +279,7 @@ func (tree *ReleaseTree) CalcChgs(endVersion, startVersion string) ([]Chg, error
 	for curr != nil && curr != lcaNode {
 		for _, change := range curr.changes {
 			if _, exists := netChanges[change.ID]; !exists {
-				return nil, fmt.Errorf("CalcChgs: change ID '%s' from start path (node '%s', version '%s') not found in end path changes (version '%s' to LCA)",
+				return nil, fmt.Errorf("change ID '%s' from start path (node '%s', version '%s') not found in end path changes (version '%s' to LCA)",
 					change.ID, curr.version, startVersion, endVersion)
 			}
 			delete(netChanges, change.ID)
@@ -208,23 +287,57 @@ func (tree *ReleaseTree) CalcChgs(endVersion, startVersion string) ([]Chg, error
 		curr = curr.parent
 	}
 
-	result := make([]Chg, 0, len(netChanges))
+	result := make([]AttributedChg, 0, len(netChanges))
 	for _, change := range netChanges {
 		result = append(result, change)
 	}
 
 	sort.Slice(result, func(i, j int) bool {
-		idNumI, errI := strconv.Atoi(result[i].ID)
-		idNumJ, errJ := strconv.Atoi(result[j].ID)
-		if errI == nil && errJ == nil {
-			return idNumI < idNumJ
-		}
-		return result[i].ID < result[j].ID
+		return lessChgID(result[i].ID, result[j].ID)
 	})
 
 	return result, nil
 }
 
+// lessChgID orders change IDs numerically when both are integers and
+// lexically otherwise.
+func lessChgID(a, b string) bool {
+	idNumI, errI := strconv.Atoi(a)
+	idNumJ, errJ := strconv.Atoi(b)
+	if errI == nil && errJ == nil {
+		return idNumI < idNumJ
+	}
+	return a < b
+}
+
+// Cumulative returns every change shipped in version, i.e. the changes of the
+// node and all its ancestors up to the root, concurrently safely.
+func (tree *ReleaseTree) Cumulative(version string) ([]Chg, error) {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+
+	n, exists := tree.nodes[version]
+	if !exists {
+		return nil, fmt.Errorf("Cumulative: version '%s' not found", version)
+	}
+
+	seen := make(map[string]bool)
+	var result []Chg
+	for curr := n; curr != nil; curr = curr.parent {
+		for _, change := range curr.changes {
+			if !seen[change.ID] {
+				seen[change.ID] = true
+				result = append(result, change)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return lessChgID(result[i].ID, result[j].ID)
+	})
+	return result, nil
+}
+
 // NodeInfo represents a single node in the tree dump.
 type NodeInfo struct {
 	Version  string   `json:"version"`
@@ -260,23 +373,93 @@ func (tree *ReleaseTree) Dump() TreeDump {
 	sort.Strings(versions)
 
 	for _, v := range versions {
-		n := tree.nodes[v]
-		info := NodeInfo{
-			Version: n.version,
-		}
-		if n.parent != nil {
-			info.FromVer = n.parent.version
-		}
-		info.Changes = make([]string, len(n.changes))
-		for i, c := range n.changes {
-			info.Changes[i] = c.ID
-		}
-		info.Children = make([]string, len(n.children))
-		for i, c := range n.children {
-			info.Children[i] = c.version
-		}
-		dump.Nodes = append(dump.Nodes, info)
+		dump.Nodes = append(dump.Nodes, nodeInfo(tree.nodes[v]))
 	}
 
 	return dump
 }
+
+// DumpSubtree returns a snapshot of the subtree rooted at root (read-locked),
+// limited to levels levels below and including root. An empty root means the
+// tree's root and zero levels means no limit. Nodes on the last level still
+// list their children so callers can page deeper.
+func (tree *ReleaseTree) DumpSubtree(root string, levels int) (TreeDump, error) {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+
+	start := tree.root
+	if root != "" {
+		start = tree.nodes[root]
+	}
+	if start == nil {
+		return TreeDump{}, fmt.Errorf("DumpSubtree: version '%s' not found", root)
+	}
+
+	var collected []*node
+	frontier := []*node{start}
+	for depth := 1; len(frontier) > 0 && (levels <= 0 || depth <= levels); depth++ {
+		collected = append(collected, frontier...)
+		var next []*node
+		for _, n := range frontier {
+			next = append(next, n.children...)
+		}
+		frontier = next
+	}
+	sort.Slice(collected, func(i, j int) bool {
+		return collected[i].version < collected[j].version
+	})
+
+	dump := TreeDump{NodeCount: len(collected), Root: start.version}
+	for _, n := range collected {
+		dump.Nodes = append(dump.Nodes, nodeInfo(n))
+	}
+	return dump, nil
+}
+
+// Node returns a snapshot of a single node, concurrently safely.
+func (tree *ReleaseTree) Node(version string) (NodeInfo, error) {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+
+	n, exists := tree.nodes[version]
+	if !exists {
+		return NodeInfo{}, fmt.Errorf("Node: version '%s' not found", version)
+	}
+	return nodeInfo(n), nil
+}
+
+// Ancestors returns the versions above version, nearest first and ending at
+// the root, concurrently safely.
+func (tree *ReleaseTree) Ancestors(version string) ([]string, error) {
+	tree.mu.RLock()
+	defer tree.mu.RUnlock()
+
+	n, exists := tree.nodes[version]
+	if !exists {
+		return nil, fmt.Errorf("Ancestors: version '%s' not found", version)
+	}
+	result := []string{}
+	for curr := n.parent; curr != nil; curr = curr.parent {
+		result = append(result, curr.version)
+	}
+	return result, nil
+}
+
+// nodeInfo converts a node to its dump representation.
+func nodeInfo(n *node) NodeInfo {
+	info := NodeInfo{
+		Version: n.version,
+	}
+	if n.parent != nil {
+		info.FromVer = n.parent.version
+	}
+	info.Changes = make([]string, len(n.changes))
+	for i, c := range n.changes {
+		info.Changes[i] = c.ID
+	}
+	info.Children = make([]string, len(n.children))
+	for i, c := range n.children {
+		info.Children[i] = c.version
+	}
+	return info
+}
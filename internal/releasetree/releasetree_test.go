@@ -253,3 +253,203 @@ func TestDump(t *testing.T) {
 		t.Fatalf("expected 8 node infos, got %d", len(dump.Nodes))
 	}
 }
+
+func TestCalcChgsAttributed(t *testing.T) {
+	tree := buildFullTree(t)
+
+	attribution := func(chgs []AttributedChg) map[string]string {
+		out := make(map[string]string, len(chgs))
+		for _, c := range chgs {
+			out[c.ID] = c.Version
+		}
+		return out
+	}
+
+	t.Run("attributes to introducing version", func(t *testing.T) {
+		result, err := tree.CalcChgsAttributed("32", "24", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := attribution(result)
+		want := map[string]string{"2": "31", "3": "31", "4": "31", "8": "32"}
+		if len(got) != len(want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		for id, ver := range want {
+			if got[id] != ver {
+				t.Fatalf("change %s: got %s, want %s", id, got[id], ver)
+			}
+		}
+	})
+
+	t.Run("skipped intermediate attributes to descendant", func(t *testing.T) {
+		skip := func(v string) bool { return v == "31" }
+		result, err := tree.CalcChgsAttributed("33", "21", skip)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ids := chgIDs(chgsOf(result)); !equalStringSlices(ids, []string{"2", "3", "4", "5", "6", "7", "10"}) {
+			t.Fatalf("unexpected ids %v", ids)
+		}
+		for _, c := range result {
+			if c.Version != "33" {
+				t.Fatalf("change %s attributed to %s, want 33", c.ID, c.Version)
+			}
+		}
+	})
+
+	t.Run("end version is never skipped", func(t *testing.T) {
+		result, err := tree.CalcChgsAttributed("31", "21", func(string) bool { return true })
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, c := range result {
+			if c.Version != "31" {
+				t.Fatalf("change %s attributed to %s, want 31", c.ID, c.Version)
+			}
+		}
+	})
+}
+
+func chgsOf(attributed []AttributedChg) []Chg {
+	out := make([]Chg, len(attributed))
+	for i, a := range attributed {
+		out[i] = a.Chg
+	}
+	return out
+}
+
+func TestAddRemoveChange(t *testing.T) {
+	tree := buildFullTree(t)
+
+	if err := tree.AddChange("24", Chg{ID: "9"}); err != nil {
+		t.Fatalf("AddChange failed: %v", err)
+	}
+	if err := tree.AddChange("24", Chg{ID: "9"}); err != nil {
+		t.Fatalf("AddChange duplicate failed: %v", err)
+	}
+	result, err := tree.CalcChgs("24", "21")
+	if err != nil {
+		t.Fatalf("CalcChgs failed: %v", err)
+	}
+	if got := chgIDs(result); !equalStringSlices(got, []string{"5", "6", "7", "9"}) {
+		t.Fatalf("after add: got %v", got)
+	}
+
+	if err := tree.RemoveChange("24", "6"); err != nil {
+		t.Fatalf("RemoveChange failed: %v", err)
+	}
+	result, err = tree.CalcChgs("24", "21")
+	if err != nil {
+		t.Fatalf("CalcChgs failed: %v", err)
+	}
+	if got := chgIDs(result); !equalStringSlices(got, []string{"5", "7", "9"}) {
+		t.Fatalf("after remove: got %v", got)
+	}
+
+	if err := tree.AddChange("99", Chg{ID: "1"}); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if err := tree.RemoveChange("99", "1"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestCumulative(t *testing.T) {
+	tree := buildFullTree(t)
+
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{"11", []string{}},
+		{"21", []string{"1"}},
+		{"24", []string{"1", "5", "6", "7"}},
+		{"33", []string{"1", "2", "3", "4", "5", "6", "7", "10"}},
+	}
+	for _, tc := range tests {
+		result, err := tree.Cumulative(tc.version)
+		if err != nil {
+			t.Fatalf("Cumulative(%s) error: %v", tc.version, err)
+		}
+		if got := chgIDs(result); !equalStringSlices(got, tc.want) {
+			t.Errorf("Cumulative(%s) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+
+	if _, err := tree.Cumulative("99"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestDumpSubtree(t *testing.T) {
+	tree := buildFullTree(t)
+
+	versions := func(d TreeDump) []string {
+		out := make([]string, len(d.Nodes))
+		for i, n := range d.Nodes {
+			out[i] = n.Version
+		}
+		return out
+	}
+
+	dump, err := tree.DumpSubtree("31", 0)
+	if err != nil {
+		t.Fatalf("DumpSubtree failed: %v", err)
+	}
+	if got := versions(dump); dump.Root != "31" || dump.NodeCount != 3 || !equalStringSlices(got, []string{"31", "32", "33"}) {
+		t.Fatalf("subtree 31: got root=%s nodes=%v", dump.Root, got)
+	}
+
+	dump, err = tree.DumpSubtree("11", 2)
+	if err != nil {
+		t.Fatalf("DumpSubtree failed: %v", err)
+	}
+	if got := versions(dump); !equalStringSlices(got, []string{"11", "21"}) {
+		t.Fatalf("depth 2: got %v", got)
+	}
+	if children := dump.Nodes[1].Children; len(children) != 3 {
+		t.Fatalf("expected truncated node to list its 3 children, got %v", children)
+	}
+
+	if _, err := tree.DumpSubtree("99", 0); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestNodeAndAncestors(t *testing.T) {
+	tree := buildFullTree(t)
+
+	n, err := tree.Node("31")
+	if err != nil {
+		t.Fatalf("Node failed: %v", err)
+	}
+	if n.FromVer != "21" || !equalStringSlices(n.Changes, []string{"2", "3", "4"}) || !equalStringSlices(n.Children, []string{"32", "33"}) {
+		t.Fatalf("unexpected node %+v", n)
+	}
+
+	tests := []struct {
+		version string
+		want    []string
+	}{
+		{"11", []string{}},
+		{"21", []string{"11"}},
+		{"33", []string{"31", "21", "11"}},
+	}
+	for _, tc := range tests {
+		got, err := tree.Ancestors(tc.version)
+		if err != nil {
+			t.Fatalf("Ancestors(%s) error: %v", tc.version, err)
+		}
+		if !equalStringSlices(got, tc.want) {
+			t.Errorf("Ancestors(%s) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+
+	if _, err := tree.Node("99"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := tree.Ancestors("99"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
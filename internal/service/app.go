@@ -2,14 +2,20 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"jiraiya/internal/db"
+	"jiraiya/internal/jiraapi"
 	"jiraiya/internal/releasetree"
 )
 
-// JiraInput is a single jira from the PUT request body.
+// JiraInput is a single jira from the PUT request body. Empty fields keep the
+// jira's stored metadata; only UpdateJira can clear a field.
 type JiraInput struct {
 	ID       string `json:"id"`
 	Title    string `json:"title"`
@@ -18,13 +24,16 @@ type JiraInput struct {
 	Relnotes string `json:"relnotes"`
 }
 
-// ReleaseInfo is the release metadata from the PUT request body.
+// ReleaseInfo is the release metadata from the PUT request body. ReleaseDate
+// must be empty or a YYYY-MM-DD date. Status is only honoured when the release
+// is created and defaults to GA.
 type ReleaseInfo struct {
 	Version     string `json:"version"`
 	FromVer     string `json:"from_ver"`
 	Platform    string `json:"platform"`
 	ReleaseDate string `json:"release_date"`
 	SubmittedBy string `json:"submitted_by"`
+	Status      string `json:"status,omitempty"`
 }
 
 // ReleaseSubmission is the full PUT request body.
@@ -33,18 +42,112 @@ type ReleaseSubmission struct {
 	Release ReleaseInfo `json:"release"`
 }
 
+// ReleasePatch holds the release metadata fields to change. Nil fields are
+// left unchanged; an empty ReleaseDate clears the date. The version, platform
+// and parent shape the tree and can only be set by a full submission.
+type ReleasePatch struct {
+	ReleaseDate *string `json:"release_date"`
+	SubmittedBy *string `json:"submitted_by"`
+}
+
+// WriteOptions carries request preconditions for SubmitRelease and
+// DeleteRelease.
+type WriteOptions struct {
+	// IfMatch is the raw If-Match header. When set, the write only proceeds
+	// if it matches the release's current ETag.
+	IfMatch string
+	// IdempotencyKey makes a submission replay-safe: a repeat with the same
+	// key and payload returns the stored result without re-applying it.
+	// DeleteRelease ignores it.
+	IdempotencyKey string
+	// Override is the admin's reason for changing a locked release. Empty
+	// means no override.
+	Override string
+}
+
+// SubmitResult is returned by SubmitRelease and the single-jira edits on
+// success.
+type SubmitResult struct {
+	ETag     string             `json:"-"`
+	Replayed bool               `json:"-"`
+	Warnings []MetadataConflict `json:"warnings,omitempty"`
+}
+
+// MetadataConflict warns that a submission changed a field of a jira that was
+// already stored, which rewrites it for every release linking that jira.
+type MetadataConflict struct {
+	JiraID string `json:"jira_id"`
+	Field  string `json:"field"`
+	Old    string `json:"old"`
+	New    string `json:"new"`
+}
+
+// DiffOptions tunes GetJirasBetweenVersions.
+type DiffOptions struct {
+	// AsOfTo returns jira metadata as it was when the "to" release was last
+	// submitted instead of the current metadata.
+	AsOfTo bool
+	// GAOnly attributes jiras introduced by non-GA intermediate releases to
+	// the nearest GA descendant on the path to the "to" release.
+	GAOnly bool
+}
+
 // Filters holds the distinct domain and impact values for a platform.
 type Filters struct {
 	Domains []string `json:"domains"`
 	Impacts []string `json:"impacts"`
 }
 
+// ListQuery pages through a list with a keyset cursor. Sort is one of
+// version, release_date, created_at or updated_at and Order is asc or desc;
+// empty values use the list's defaults. A zero Limit uses the default page
+// size. Cursor is the NextCursor of the previous page.
+type ListQuery struct {
+	Sort   string
+	Order  string
+	Limit  int
+	Cursor string
+}
+
+// VersionQuery narrows GetVersions. Zero times leave that bound open and an
+// empty Statuses matches every status.
+type VersionQuery struct {
+	Since    time.Time
+	Until    time.Time
+	Statuses []string
+	ListQuery
+}
+
+// VersionList is one page of GetVersions results. Total counts every match and
+// NextCursor is empty on the last page.
+type VersionList struct {
+	Versions   []VersionInfo
+	Total      int64
+	NextCursor string
+}
+
+// ReleaseList is one page of ListReleases results. Total counts every release
+// on the platform and NextCursor is empty on the last page.
+type ReleaseList struct {
+	Releases   []ReleaseOutput
+	Total      int64
+	NextCursor string
+}
+
+// TreeQuery narrows GetTreeInfo to the subtree under Root, or the whole tree
+// when Root is empty, limited to Depth levels. Zero Depth means no limit.
+type TreeQuery struct {
+	Root  string
+	Depth int
+}
+
 // VersionInfo is a release version returned by GetVersions.
 type VersionInfo struct {
 	Version     string `json:"version"`
 	FromVer     string `json:"from_ver"`
 	ReleaseDate string `json:"release_date"`
 	SubmittedBy string `json:"submitted_by"`
+	Status      string `json:"status"`
 }
 
 // ReleaseOutput is a release returned to the client.
@@ -54,15 +157,180 @@ type ReleaseOutput struct {
 	Platform    string `json:"platform"`
 	ReleaseDate string `json:"release_date"`
 	SubmittedBy string `json:"submitted_by"`
+	Status      string `json:"status"`
+	Locked      bool   `json:"locked,omitempty"`
+	ETag        string `json:"-"`
 }
 
-// JiraOutput is a jira returned to the client.
+// JiraOutput is a jira returned to the client. Release is the version that
+// introduced the jira, set only on diffs.
 type JiraOutput struct {
 	ID       string `json:"id"`
 	Title    string `json:"title"`
 	Impact   string `json:"impact"`
 	Domain   string `json:"domain"`
 	Relnotes string `json:"relnotes"`
+	Release  string `json:"release,omitempty"`
+}
+
+// JiraDetail is a single jira together with the releases that link it.
+type JiraDetail struct {
+	JiraOutput
+	Releases []string `json:"releases"`
+}
+
+// JiraSearch narrows SearchJiras. Empty fields match everything. Query is a
+// web-style full-text search over title and relnotes. A zero Limit uses the
+// default page size.
+type JiraSearch struct {
+	Query  string
+	Domain string
+	Impact string
+	Limit  int
+	Offset int
+}
+
+// ContentsQuery narrows GetReleaseContents. Empty fields match everything and
+// a zero Limit uses the default page size.
+type ContentsQuery struct {
+	Domain string
+	Impact string
+	Limit  int
+	Offset int
+}
+
+// JiraPage is one page of jiras. Total counts every match.
+type JiraPage struct {
+	Jiras  []JiraOutput `json:"jiras"`
+	Total  int64        `json:"total"`
+	Limit  int          `json:"limit"`
+	Offset int          `json:"offset"`
+}
+
+// JiraPatch holds the metadata fields to change on a jira. Nil fields are
+// left unchanged.
+type JiraPatch struct {
+	Title    *string `json:"title"`
+	Impact   *string `json:"impact"`
+	Domain   *string `json:"domain"`
+	Relnotes *string `json:"relnotes"`
+}
+
+// VersionRef identifies a release on a specific platform.
+type VersionRef struct {
+	Platform string `json:"platform"`
+	Version  string `json:"version"`
+}
+
+// Comparison splits the jiras shipped in two releases, possibly on different
+// platforms, into those only one side has and those both have.
+type Comparison struct {
+	Left      VersionRef   `json:"left"`
+	Right     VersionRef   `json:"right"`
+	OnlyLeft  []JiraOutput `json:"only_left"`
+	OnlyRight []JiraOutput `json:"only_right"`
+	Common    []JiraOutput `json:"common"`
+}
+
+// FeedQuery narrows GetFeed to the release Branch and its descendants, or the
+// whole platform when Branch is empty. A zero Limit uses the default feed
+// size.
+type FeedQuery struct {
+	Branch string
+	Limit  int
+}
+
+// Feed is the newest releases of a platform, each with the jiras it added
+// over its parent.
+type Feed struct {
+	Platform string
+	Updated  time.Time
+	Entries  []FeedEntry
+}
+
+// FeedEntry is a single release in a Feed.
+type FeedEntry struct {
+	Version     string
+	FromVer     string
+	ReleaseDate string
+	SubmittedBy string
+	Status      string
+	Updated     time.Time
+	Jiras       []JiraOutput
+}
+
+// WebhookInput is the body of a webhook subscription request. Events lists the
+// event types to deliver; empty means every type.
+type WebhookInput struct {
+	Platform string   `json:"platform"`
+	URL      string   `json:"url"`
+	Secret   string   `json:"secret"`
+	Events   []string `json:"events"`
+}
+
+// Webhook is a webhook subscription returned to the client. The secret is
+// write-only and never returned.
+type Webhook struct {
+	ID        int64     `json:"id"`
+	Platform  string    `json:"platform"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookEvent is the JSON body delivered to subscribers. Jiras is the
+// release's diff against its parent, i.e. the jiras it introduces. For a
+// deletion it describes the release as it was.
+type WebhookEvent struct {
+	Type       string        `json:"type"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Actor      string        `json:"actor"`
+	Release    ReleaseOutput `json:"release"`
+	Jiras      []JiraOutput  `json:"jiras"`
+}
+
+// ReleaseEvent is a WebhookEvent read back from the event log. Events are
+// committed in ID order, so a reader resumes by asking for events after the
+// last ID it saw.
+type ReleaseEvent struct {
+	ID int64 `json:"id"`
+	WebhookEvent
+}
+
+// EventQuery reads the release event log after AfterID, narrowed to Platform
+// if it is set. A zero Limit uses the default page size.
+type EventQuery struct {
+	Platform string
+	AfterID  int64
+	Limit    int
+}
+
+// WebhookDelivery is one event queued for one subscription, with the outcome
+// of its latest attempt.
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	EventID        int64      `json:"event_id"`
+	SubscriptionID int64      `json:"subscription_id"`
+	Platform       string     `json:"platform"`
+	EventType      string     `json:"event_type"`
+	Version        string     `json:"version"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// DeliveryQuery narrows ListWebhookDeliveries. Zero fields match everything
+// and a zero Limit uses the default page size.
+type DeliveryQuery struct {
+	SubscriptionID int64
+	Platform       string
+	Status         string
+	Limit          int
+	Offset         int
 }
 
 // TreeInfo is the admin tree introspection response.
@@ -73,16 +341,141 @@ type TreeInfo struct {
 	Nodes     []releasetree.NodeInfo `json:"nodes"`
 }
 
+// ReleaseNode is a release's place in its platform tree. Jiras are the ids
+// the release adds over its parent and Ancestors runs from the parent up to
+// the root. Parent is empty for the root.
+type ReleaseNode struct {
+	Version   string
+	Parent    string
+	Children  []string
+	Ancestors []string
+	Jiras     []string
+}
+
+// TokenInput is the body of a token creation request. Role grants that role
+// on every platform; Grants scope roles to single platforms. At least one of
+// the two is required.
+type TokenInput struct {
+	Name   string  `json:"name"`
+	Role   string  `json:"role,omitempty"`
+	Grants []Grant `json:"grants,omitempty"`
+}
+
+// Grant gives a token a role on one platform, or on every platform when
+// Platform is "*".
+type Grant struct {
+	Platform string `json:"platform"`
+	Role     string `json:"role"`
+}
+
+// APIToken is an API token returned to the client. Only its hash is stored,
+// so the token itself is never returned after creation.
+type APIToken struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Role       string     `json:"role"`
+	Grants     []Grant    `json:"grants"`
+	CreatedBy  string     `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// CreatedToken is a newly created API token together with the token itself,
+// which is shown this once.
+type CreatedToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// AuditEntry is a single submit or delete recorded in a release's history.
+type AuditEntry struct {
+	ID        int64           `json:"id"`
+	Version   string          `json:"version"`
+	Platform  string          `json:"platform"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+	Added     []string        `json:"added"`
+	Removed   []string        `json:"removed"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
 // Service defines the business logic interface.
 type Service interface {
-	SubmitRelease(ctx context.Context, sub ReleaseSubmission) error
-	DeleteRelease(ctx context.Context, version string) error
+	SubmitRelease(ctx context.Context, sub ReleaseSubmission, opts WriteOptions) (*SubmitResult, error)
+	DeleteRelease(ctx context.Context, version string, opts WriteOptions) error
 	GetReleases(ctx context.Context, version, platform string) ([]ReleaseOutput, error)
 	GetFilters(ctx context.Context, platform string) (*Filters, error)
-	GetVersions(ctx context.Context, platform string) ([]VersionInfo, error)
-	GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string) ([]JiraOutput, error)
-	GetTreeInfo(ctx context.Context, platform string) (*TreeInfo, error)
+	ListReleases(ctx context.Context, platform string, lq ListQuery) (*ReleaseList, error)
+	GetVersions(ctx context.Context, platform string, vq VersionQuery) (*VersionList, error)
+	GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string, opts DiffOptions) ([]JiraOutput, error)
+	GetTreeInfo(ctx context.Context, platform string, tq TreeQuery) (*TreeInfo, error)
+	ListPlatforms(ctx context.Context) ([]string, error)
+	GetReleaseNode(ctx context.Context, platform, version string) (*ReleaseNode, error)
+	GetReleasesByVersions(ctx context.Context, versions []string) ([]ReleaseOutput, error)
+	GetJirasByIDs(ctx context.Context, ids []string) ([]JiraOutput, error)
+	GetReleaseVersionsByJiras(ctx context.Context, ids []string) (map[string][]string, error)
+	GetReleaseHistory(ctx context.Context, version string) ([]AuditEntry, error)
+	TransitionRelease(ctx context.Context, version, status string, opts WriteOptions) (*ReleaseOutput, error)
+	SetReleaseLock(ctx context.Context, version string, locked bool, opts WriteOptions) (*ReleaseOutput, error)
+	RestoreRelease(ctx context.Context, version string, opts WriteOptions) (*ReleaseOutput, error)
+	PurgeDeletedReleases(ctx context.Context) (int, error)
+	PurgeIdempotencyKeys(ctx context.Context) (int, error)
+	GetJira(ctx context.Context, id string) (*JiraDetail, error)
+	SearchJiras(ctx context.Context, js JiraSearch) (*JiraPage, error)
+	UpdateJira(ctx context.Context, id string, patch JiraPatch) (*JiraOutput, error)
+	AddReleaseJira(ctx context.Context, version string, j JiraInput, opts WriteOptions) (*SubmitResult, error)
+	RemoveReleaseJira(ctx context.Context, version, jiraID string, opts WriteOptions) (*SubmitResult, error)
+	UpdateRelease(ctx context.Context, version string, patch ReleasePatch, opts WriteOptions) (*ReleaseOutput, error)
+	CompareVersions(ctx context.Context, left, right VersionRef) (*Comparison, error)
+	GetReleaseContents(ctx context.Context, version string, cq ContentsQuery) (*JiraPage, error)
+	GetFeed(ctx context.Context, platform string, fq FeedQuery) (*Feed, error)
+	CreateWebhook(ctx context.Context, in WebhookInput) (*Webhook, error)
+	ListWebhooks(ctx context.Context, platform string) ([]Webhook, error)
+	GetWebhook(ctx context.Context, id int64) (*Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	ListWebhookDeliveries(ctx context.Context, dq DeliveryQuery) ([]WebhookDelivery, error)
+	RetryWebhookDelivery(ctx context.Context, id int64) error
+	ListReleaseEvents(ctx context.Context, eq EventQuery) ([]ReleaseEvent, error)
+	LatestReleaseEventID(ctx context.Context) (int64, error)
+	DeliverWebhooks(ctx context.Context) (int, error)
+	EnrichJiras(ctx context.Context) (int, error)
+	Authenticate(ctx context.Context, token string) (*Identity, error)
+	CreateToken(ctx context.Context, in TokenInput) (*CreatedToken, error)
+	ListTokens(ctx context.Context) ([]APIToken, error)
+	RevokeToken(ctx context.Context, id int64) error
+	EnsureToken(ctx context.Context, name, role, token string) error
 	LoadTrees(ctx context.Context) error
+	// Close stops the service's background work and waits for it to end. Call
+	// it before closing the pool.
+	Close()
+}
+
+// Config holds tunables for the service. The zero value uses defaults.
+type Config struct {
+	// IdempotencyRetention is how long idempotency keys are replayed.
+	IdempotencyRetention time.Duration
+	// AutoLockDays freezes a release this many days after its release date.
+	// Zero disables automatic locking.
+	AutoLockDays int
+	// DeletedRetention is how long soft-deleted releases can be restored
+	// before the purge job removes them.
+	DeletedRetention time.Duration
+	// WebhookMaxAttempts is how many times a webhook delivery is tried before
+	// it moves to the dead-letter list.
+	WebhookMaxAttempts int
+	// WebhookBackoff is the delay before the first retry of a failed webhook
+	// delivery. Each further retry doubles it.
+	WebhookBackoff time.Duration
+	// WebhookTimeout bounds a single webhook delivery request.
+	WebhookTimeout time.Duration
+	// Jira is the Jira REST API used to fill in empty jira metadata.
+	// Enrichment is off when Jira.BaseURL is empty.
+	Jira jiraapi.Config
+	// EnrichmentTTL is how long a Jira lookup is cached before a jira that
+	// still has empty fields is looked up again.
+	EnrichmentTTL time.Duration
 }
 
 type svc struct {
@@ -90,16 +483,39 @@ type svc struct {
 	q    *db.Queries
 	tm   *TreeManager
 	log  *slog.Logger
+	cfg  Config
+
+	// ctx bounds background work started by the service; Close cancels it
+	// and waits on bg.
+	ctx  context.Context
+	stop context.CancelFunc
+	bg   sync.WaitGroup
+
+	// jira is nil when enrichment is off.
+	jira          *jiraapi.Client
+	enrichPending atomic.Bool
+	enrichRunning atomic.Bool
 }
 
 // New creates a new Service backed by the given pool.
-func New(pool *pgxpool.Pool, log *slog.Logger) Service {
-	return &svc{
+func New(pool *pgxpool.Pool, log *slog.Logger, cfg Config) Service {
+	s := &svc{
 		pool: pool,
 		q:    db.New(pool),
 		tm:   NewTreeManager(log),
 		log:  log,
+		cfg:  cfg,
+	}
+	s.ctx, s.stop = context.WithCancel(context.Background())
+	if cfg.Jira.BaseURL != "" {
+		s.jira = jiraapi.New(cfg.Jira)
 	}
+	return s
+}
+
+func (s *svc) Close() {
+	s.stop()
+	s.bg.Wait()
 }
 
 // LoadTrees loads all platform trees from the database at startup.
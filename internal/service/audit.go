@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"jiraiya/internal/db"
+)
+
+// Audit actions recorded in release_audit.
+const (
+	auditSubmit = "submit"
+	auditDelete = "delete"
+)
+
+// actorFrom returns the name of the authenticated caller in ctx, or fallback
+// if the call was not authenticated.
+func actorFrom(ctx context.Context, fallback string) string {
+	if id := IdentityFrom(ctx); id != nil && id.Name != "" {
+		return id.Name
+	}
+	return fallback
+}
+
+// diffJiraIDs returns the ids present only in after (added) and only in
+// before (removed), both sorted.
+func diffJiraIDs(before, after []string) (added, removed []string) {
+	inBefore := make(map[string]bool, len(before))
+	for _, id := range before {
+		inBefore[id] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, id := range after {
+		inAfter[id] = true
+	}
+
+	added = []string{}
+	for id := range inAfter {
+		if !inBefore[id] {
+			added = append(added, id)
+		}
+	}
+	removed = []string{}
+	for id := range inBefore {
+		if !inAfter[id] {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func (s *svc) GetReleaseHistory(ctx context.Context, version string) ([]AuditEntry, error) {
+	rows, err := s.q.GetReleaseAudit(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get release audit %s: %w", version, err)
+	}
+	out := make([]AuditEntry, len(rows))
+	for i, r := range rows {
+		if err := requirePlatformRole(ctx, r.Platform, RoleReader); err != nil {
+			return nil, err
+		}
+		out[i] = auditEntryFromRow(r)
+	}
+	return out, nil
+}
+
+func auditEntryFromRow(r db.ReleaseAudit) AuditEntry {
+	return AuditEntry{
+		ID:        r.ID,
+		Version:   r.ReleaseVersion,
+		Platform:  r.Platform,
+		Action:    r.Action,
+		Actor:     r.Actor,
+		Payload:   r.Payload,
+		Added:     r.AddedJiras,
+		Removed:   r.RemovedJiras,
+		CreatedAt: r.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"jiraiya/internal/db"
+)
+
+// API token roles. Each role includes the ones before it: a submitter can
+// read and an admin can do anything.
+const (
+	RoleReader    = "reader"
+	RoleSubmitter = "submitter"
+	RoleAdmin     = "admin"
+)
+
+// AllPlatforms is the grant platform that covers every platform.
+const AllPlatforms = "*"
+
+// maxPlatformLen matches the longest platform a grant can name.
+const maxPlatformLen = 64
+
+// tokenPrefix marks jiraiya tokens so they are easy to spot in logs and
+// secret scanners.
+const tokenPrefix = "jy_"
+
+func roleRank(role string) int {
+	switch role {
+	case RoleReader:
+		return 1
+	case RoleSubmitter:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// ValidRole reports whether role is one of the API token roles.
+func ValidRole(role string) bool {
+	return roleRank(role) > 0
+}
+
+// Identity is the authenticated caller behind a request. Role is the highest
+// role the caller holds anywhere and gates which endpoints it may call;
+// Grants maps platforms (or AllPlatforms) to the role held on each and gates
+// what it may do to a given platform's releases.
+type Identity struct {
+	TokenID int64
+	Name    string
+	Role    string
+	Grants  map[string]string
+}
+
+// Can reports whether the identity's role includes role.
+func (id *Identity) Can(role string) bool {
+	return id != nil && roleRank(id.Role) >= roleRank(role) && roleRank(role) > 0
+}
+
+// CanOn reports whether the identity holds role on platform, either through
+// a grant for that platform or through a grant for all platforms.
+func (id *Identity) CanOn(platform, role string) bool {
+	if id == nil || roleRank(role) == 0 {
+		return false
+	}
+	rank := roleRank(id.Grants[AllPlatforms])
+	if platform != AllPlatforms {
+		rank = max(rank, roleRank(id.Grants[platform]))
+	}
+	return rank >= roleRank(role)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the authenticated caller. The
+// caller's name is the actor in audit records and the submitter of releases
+// it submits.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFrom returns the caller stored in ctx, or nil if the call did not
+// come through an authenticated API.
+func IdentityFrom(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
+
+// requirePlatformRole fails with ErrForbidden if an authenticated caller
+// lacks role on platform. Calls without an identity come from the server
+// itself and are allowed.
+func requirePlatformRole(ctx context.Context, platform, role string) error {
+	if id := IdentityFrom(ctx); id != nil && !id.CanOn(platform, role) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// grantedPlatforms returns the platforms on which the caller holds role, for
+// queries that list across platforms. all is true when no filter applies:
+// the call has no identity or the caller holds role on every platform.
+func grantedPlatforms(ctx context.Context, role string) (platforms []string, all bool) {
+	id := IdentityFrom(ctx)
+	if id == nil || id.CanOn(AllPlatforms, role) {
+		return nil, true
+	}
+	platforms = []string{}
+	for p := range id.Grants {
+		if p != AllPlatforms && id.CanOn(p, role) {
+			platforms = append(platforms, p)
+		}
+	}
+	sort.Strings(platforms)
+	return platforms, false
+}
+
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (s *svc) Authenticate(ctx context.Context, token string) (*Identity, error) {
+	if token == "" {
+		return nil, ErrUnauthorized
+	}
+	row, err := s.q.GetAPITokenByHash(ctx, hashToken(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUnauthorized
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api token: %w", err)
+	}
+	grants, err := s.q.GetAPITokenGrants(ctx, row.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get api token grants: %w", err)
+	}
+	if err := s.q.TouchAPIToken(ctx, row.ID); err != nil {
+		// Authentication still succeeded; only the usage record is stale.
+		s.log.Warn("touch api token failed", "id", row.ID, "error", err)
+	}
+	id := &Identity{TokenID: row.ID, Name: row.Name, Role: row.Role, Grants: make(map[string]string, len(grants))}
+	for _, g := range grants {
+		id.Grants[g.Platform] = g.Role
+	}
+	return id, nil
+}
+
+// tokenGrants validates in and returns its grants, with Role folded in as a
+// grant on all platforms, and the highest role among them.
+func tokenGrants(in TokenInput) ([]Grant, string, error) {
+	var details []ValidationDetail
+	if strings.TrimSpace(in.Name) == "" {
+		details = append(details, ValidationDetail{Reason: "name is required"})
+	}
+	grants := in.Grants
+	if in.Role != "" {
+		grants = append([]Grant{{Platform: AllPlatforms, Role: in.Role}}, grants...)
+	}
+	if len(grants) == 0 {
+		details = append(details, ValidationDetail{Reason: "role or grants is required"})
+	}
+	top := ""
+	seen := make(map[string]bool, len(grants))
+	for i, g := range grants {
+		switch {
+		case g.Platform == "" || len(g.Platform) > maxPlatformLen:
+			details = append(details, ValidationDetail{Index: i, Reason: fmt.Sprintf("grant platform must be 1 to %d characters", maxPlatformLen)})
+		case seen[g.Platform]:
+			details = append(details, ValidationDetail{Index: i, Reason: fmt.Sprintf("platform %q is granted more than once", g.Platform)})
+		case !ValidRole(g.Role):
+			details = append(details, ValidationDetail{Index: i, Reason: fmt.Sprintf("unknown role %q", g.Role)})
+		}
+		seen[g.Platform] = true
+		if roleRank(g.Role) > roleRank(top) {
+			top = g.Role
+		}
+	}
+	if len(details) > 0 {
+		return nil, "", &ValidationError{Details: details}
+	}
+	return grants, top, nil
+}
+
+func (s *svc) CreateToken(ctx context.Context, in TokenInput) (*CreatedToken, error) {
+	// A platform admin must not be able to mint itself wider grants
+	if err := requirePlatformRole(ctx, AllPlatforms, RoleAdmin); err != nil {
+		return nil, err
+	}
+	grants, role, err := tokenGrants(in)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	row, err := qtx.InsertAPIToken(ctx, db.InsertAPITokenParams{
+		Name:      in.Name,
+		Role:      role,
+		TokenHash: hashToken(token),
+		CreatedBy: actorFrom(ctx, ""),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert api token: %w", err)
+	}
+	for _, g := range grants {
+		if err := qtx.InsertAPITokenGrant(ctx, db.InsertAPITokenGrantParams{
+			TokenID:  row.ID,
+			Platform: g.Platform,
+			Role:     g.Role,
+		}); err != nil {
+			return nil, fmt.Errorf("insert api token grant: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	s.log.Info("api token created", "id", row.ID, "name", row.Name, "role", row.Role, "grants", len(grants), "actor", actorFrom(ctx, ""))
+	out := tokenOutput(row)
+	out.Grants = grants
+	return &CreatedToken{APIToken: out, Token: token}, nil
+}
+
+func (s *svc) ListTokens(ctx context.Context) ([]APIToken, error) {
+	if err := requirePlatformRole(ctx, AllPlatforms, RoleAdmin); err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListAPITokens(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+	grantRows, err := s.q.ListAPITokenGrants(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list api token grants: %w", err)
+	}
+	grants := make(map[int64][]Grant)
+	for _, g := range grantRows {
+		grants[g.TokenID] = append(grants[g.TokenID], Grant{Platform: g.Platform, Role: g.Role})
+	}
+	out := make([]APIToken, len(rows))
+	for i, r := range rows {
+		out[i] = tokenOutput(r)
+		if g, ok := grants[r.ID]; ok {
+			out[i].Grants = g
+		}
+	}
+	return out, nil
+}
+
+func (s *svc) RevokeToken(ctx context.Context, id int64) error {
+	if err := requirePlatformRole(ctx, AllPlatforms, RoleAdmin); err != nil {
+		return err
+	}
+	n, err := s.q.RevokeAPIToken(ctx, id)
+	if err != nil {
+		return fmt.Errorf("revoke api token %d: %w", id, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	s.log.Info("api token revoked", "id", id, "actor", actorFrom(ctx, ""))
+	return nil
+}
+
+func (s *svc) EnsureToken(ctx context.Context, name, role, token string) error {
+	if !ValidRole(role) {
+		return fmt.Errorf("unknown role %q", role)
+	}
+	if token == "" {
+		return errors.New("token is empty")
+	}
+	if err := s.q.EnsureAPIToken(ctx, db.EnsureAPITokenParams{
+		Name:      name,
+		Role:      role,
+		TokenHash: hashToken(token),
+	}); err != nil {
+		return fmt.Errorf("ensure api token: %w", err)
+	}
+	if err := s.q.EnsureAPITokenGrant(ctx, hashToken(token)); err != nil {
+		return fmt.Errorf("ensure api token grant: %w", err)
+	}
+	return nil
+}
+
+func tokenOutput(r db.ApiToken) APIToken {
+	out := APIToken{
+		ID:        r.ID,
+		Name:      r.Name,
+		Role:      r.Role,
+		Grants:    []Grant{},
+		CreatedBy: r.CreatedBy,
+		CreatedAt: r.CreatedAt.Time,
+	}
+	if r.LastUsedAt.Valid {
+		t := r.LastUsedAt.Time
+		out.LastUsedAt = &t
+	}
+	if r.RevokedAt.Valid {
+		t := r.RevokedAt.Time
+		out.RevokedAt = &t
+	}
+	return out
+}
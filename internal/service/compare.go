@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"jiraiya/internal/db"
+)
+
+func (s *svc) CompareVersions(ctx context.Context, left, right VersionRef) (*Comparison, error) {
+	leftIDs, err := s.cumulativeIDs(ctx, left)
+	if err != nil {
+		return nil, err
+	}
+	rightIDs, err := s.cumulativeIDs(ctx, right)
+	if err != nil {
+		return nil, err
+	}
+
+	inRight := make(map[string]bool, len(rightIDs))
+	for _, id := range rightIDs {
+		inRight[id] = true
+	}
+	inLeft := make(map[string]bool, len(leftIDs))
+	var onlyLeft, common []string
+	for _, id := range leftIDs {
+		inLeft[id] = true
+		if inRight[id] {
+			common = append(common, id)
+		} else {
+			onlyLeft = append(onlyLeft, id)
+		}
+	}
+	var onlyRight []string
+	for _, id := range rightIDs {
+		if !inLeft[id] {
+			onlyRight = append(onlyRight, id)
+		}
+	}
+
+	all := append(leftIDs[:len(leftIDs):len(leftIDs)], onlyRight...)
+	jiras, err := s.q.GetJirasByIDs(ctx, all)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras by ids: %w", err)
+	}
+	byID := make(map[string]db.Jira, len(jiras))
+	for _, j := range jiras {
+		byID[j.ID] = j
+	}
+	outputs := func(ids []string) []JiraOutput {
+		out := make([]JiraOutput, 0, len(ids))
+		for _, id := range ids {
+			if j, ok := byID[id]; ok {
+				out = append(out, jiraOutput(j))
+			}
+		}
+		return out
+	}
+
+	return &Comparison{
+		Left:      left,
+		Right:     right,
+		OnlyLeft:  outputs(onlyLeft),
+		OnlyRight: outputs(onlyRight),
+		Common:    outputs(common),
+	}, nil
+}
+
+// cumulativeIDs returns the ids of every jira shipped in ref, ordered by id
+// (numerically where both ids are numbers).
+// A version that does not exist on the given platform yields ErrNotFound.
+func (s *svc) cumulativeIDs(ctx context.Context, ref VersionRef) ([]string, error) {
+	if err := requirePlatformRole(ctx, ref.Platform, RoleReader); err != nil {
+		return nil, err
+	}
+	rel, err := s.getRelease(ctx, ref.Version)
+	if err != nil {
+		return nil, err
+	}
+	if rel.Platform != ref.Platform {
+		return nil, ErrNotFound
+	}
+
+	chgs, err := s.tm.Cumulative(ref.Platform, ref.Version)
+	if err != nil {
+		return nil, fmt.Errorf("cumulative changes for %s:%s: %w", ref.Platform, ref.Version, err)
+	}
+	ids := make([]string, len(chgs))
+	for i, c := range chgs {
+		ids[i] = c.ID
+	}
+	return ids, nil
+}
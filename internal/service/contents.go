@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"jiraiya/internal/db"
+)
+
+func (s *svc) GetReleaseContents(ctx context.Context, version string, cq ContentsQuery) (*JiraPage, error) {
+	var err error
+	if cq.Limit, err = pageBounds(cq.Limit, cq.Offset); err != nil {
+		return nil, err
+	}
+
+	rel, err := s.getRelease(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := s.cumulativeIDs(ctx, VersionRef{Platform: rel.Platform, Version: version})
+	if err != nil {
+		return nil, err
+	}
+
+	jiras, err := s.q.GetJirasByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras by ids: %w", err)
+	}
+	byID := make(map[string]db.Jira, len(jiras))
+	for _, j := range jiras {
+		byID[j.ID] = j
+	}
+
+	// Filter in id order, so pages are stable, then cut the requested page.
+	var matched []JiraOutput
+	for _, id := range ids {
+		j, ok := byID[id]
+		if !ok || (cq.Domain != "" && j.Domain != cq.Domain) || (cq.Impact != "" && j.Impact != cq.Impact) {
+			continue
+		}
+		matched = append(matched, jiraOutput(j))
+	}
+	start := min(cq.Offset, len(matched))
+	end := min(start+cq.Limit, len(matched))
+
+	page := &JiraPage{Jiras: make([]JiraOutput, end-start), Total: int64(len(matched)), Limit: cq.Limit, Offset: cq.Offset}
+	copy(page.Jiras, matched[start:end])
+	return page, nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"jiraiya/internal/db"
+)
+
+// Sort orders accepted by ListQuery.
+const (
+	orderAsc  = "asc"
+	orderDesc = "desc"
+)
+
+// sortKeys are the ListQuery sort columns understood by the keyset queries.
+var sortKeys = map[string]bool{
+	"version":      true,
+	"release_date": true,
+	"created_at":   true,
+	"updated_at":   true,
+}
+
+// cursorTimeLayout renders created_at and updated_at in cursors. Postgres keeps
+// microseconds, so the round trip is exact.
+const cursorTimeLayout = time.RFC3339Nano
+
+// cursor is the decoded form of a keyset page cursor. It carries the sort it
+// was issued for so it cannot be replayed against a different ordering. Key is
+// the last row's sort column: a YYYY-MM-DD date, empty for an undated
+// release, or a timestamp in cursorTimeLayout. The version sort needs none.
+type cursor struct {
+	Sort    string `json:"s"`
+	Order   string `json:"o"`
+	Key     string `json:"k"`
+	Version string `json:"v"`
+}
+
+func (c cursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// keyset is a validated ListQuery ready for a keyset query.
+type keyset struct {
+	sort       string
+	order      string
+	limit      int
+	descending bool
+	after      cursor
+	afterDate  pgtype.Date
+	afterTime  pgtype.Timestamptz
+}
+
+// parseListQuery validates lq, fills in the defaults and decodes its cursor.
+func parseListQuery(lq ListQuery, defaultSort, defaultOrder string) (keyset, error) {
+	ks := keyset{sort: lq.Sort, order: lq.Order}
+	if ks.sort == "" {
+		ks.sort = defaultSort
+	}
+	if ks.order == "" {
+		ks.order = defaultOrder
+	}
+	if !sortKeys[ks.sort] {
+		return ks, &ValidationError{Details: []ValidationDetail{{Reason: fmt.Sprintf("unknown sort %q", ks.sort)}}}
+	}
+	if ks.order != orderAsc && ks.order != orderDesc {
+		return ks, &ValidationError{Details: []ValidationDetail{{Reason: "order must be asc or desc"}}}
+	}
+	ks.descending = ks.order == orderDesc
+
+	limit, err := pageBounds(lq.Limit, 0)
+	if err != nil {
+		return ks, err
+	}
+	ks.limit = limit
+
+	if lq.Cursor != "" {
+		invalid := &ValidationError{Details: []ValidationDetail{{Reason: "invalid cursor"}}}
+		data, err := base64.RawURLEncoding.DecodeString(lq.Cursor)
+		if err != nil {
+			return ks, invalid
+		}
+		if err := json.Unmarshal(data, &ks.after); err != nil || ks.after.Version == "" {
+			return ks, invalid
+		}
+		if ks.after.Sort != ks.sort || ks.after.Order != ks.order {
+			return ks, &ValidationError{Details: []ValidationDetail{{Reason: "cursor was issued for a different sort or order"}}}
+		}
+		switch ks.sort {
+		case "release_date":
+			if ks.afterDate, err = parseDate(ks.after.Key); err != nil {
+				return ks, invalid
+			}
+		case "created_at", "updated_at":
+			t, err := time.Parse(cursorTimeLayout, ks.after.Key)
+			if err != nil {
+				return ks, invalid
+			}
+			ks.afterTime = pgtype.Timestamptz{Time: t, Valid: true}
+		}
+	}
+	return ks, nil
+}
+
+// next returns the cursor following r.
+func (ks keyset) next(r db.Release) string {
+	key := ""
+	switch ks.sort {
+	case "release_date":
+		key = formatDate(r.ReleaseDate)
+	case "created_at":
+		key = r.CreatedAt.Time.UTC().Format(cursorTimeLayout)
+	case "updated_at":
+		key = r.UpdatedAt.Time.UTC().Format(cursorTimeLayout)
+	}
+	return cursor{Sort: ks.sort, Order: ks.order, Key: key, Version: r.Version}.encode()
+}
+
+// releaseFilter narrows a keyset release list. statuses must not be nil: a
+// NULL array matches nothing.
+type releaseFilter struct {
+	since, until pgtype.Date
+	statuses     []string
+}
+
+// listReleasePage reads the page of platform's live releases that ks selects,
+// plus one extra row to learn whether another page follows.
+func (s *svc) listReleasePage(ctx context.Context, platform string, ks keyset, f releaseFilter) ([]db.Release, error) {
+	limit := int32(ks.limit + 1)
+	switch ks.sort {
+	case "release_date":
+		return s.q.ListReleasesByReleaseDate(ctx, db.ListReleasesByReleaseDateParams{
+			Descending:   ks.descending,
+			Platform:     platform,
+			Since:        f.since,
+			Until:        f.until,
+			Statuses:     f.statuses,
+			AfterVersion: ks.after.Version,
+			AfterDate:    ks.afterDate,
+			PageLimit:    limit,
+		})
+	case "created_at":
+		return s.q.ListReleasesByCreatedAt(ctx, db.ListReleasesByCreatedAtParams{
+			Descending:   ks.descending,
+			Platform:     platform,
+			Since:        f.since,
+			Until:        f.until,
+			Statuses:     f.statuses,
+			AfterVersion: ks.after.Version,
+			AfterTime:    ks.afterTime,
+			PageLimit:    limit,
+		})
+	case "updated_at":
+		return s.q.ListReleasesByUpdatedAt(ctx, db.ListReleasesByUpdatedAtParams{
+			Descending:   ks.descending,
+			Platform:     platform,
+			Since:        f.since,
+			Until:        f.until,
+			Statuses:     f.statuses,
+			AfterVersion: ks.after.Version,
+			AfterTime:    ks.afterTime,
+			PageLimit:    limit,
+		})
+	}
+	return s.q.ListReleasesByVersion(ctx, db.ListReleasesByVersionParams{
+		Descending:   ks.descending,
+		Platform:     platform,
+		Since:        f.since,
+		Until:        f.until,
+		Statuses:     f.statuses,
+		AfterVersion: ks.after.Version,
+		PageLimit:    limit,
+	})
+}
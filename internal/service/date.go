@@ -0,0 +1,39 @@
+package service
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DateLayout is the only accepted format for release dates.
+const DateLayout = "2006-01-02"
+
+// parseDate converts a YYYY-MM-DD string to a pgtype.Date. An empty string
+// yields a NULL date.
+func parseDate(s string) (pgtype.Date, error) {
+	if s == "" {
+		return pgtype.Date{}, nil
+	}
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return pgtype.Date{}, err
+	}
+	return pgtype.Date{Time: t, Valid: true}, nil
+}
+
+// formatDate renders a date as YYYY-MM-DD, or "" for NULL.
+func formatDate(d pgtype.Date) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Time.Format(DateLayout)
+}
+
+// toDate converts an optional time to a pgtype.Date; the zero time is NULL.
+func toDate(t time.Time) pgtype.Date {
+	if t.IsZero() {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: t, Valid: true}
+}
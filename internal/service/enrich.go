@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"jiraiya/internal/db"
+	"jiraiya/internal/jiraapi"
+)
+
+// defaultEnrichmentTTL is used when Config.EnrichmentTTL is zero.
+const defaultEnrichmentTTL = 24 * time.Hour
+
+// enrichBatchSize is how many jiras EnrichJiras looks up per query.
+const enrichBatchSize = 50
+
+// enrichRetryInterval is how long a jira whose lookup failed waits before it
+// is looked up again, unless the cache TTL is shorter.
+const enrichRetryInterval = 15 * time.Minute
+
+func (s *svc) enrichmentTTL() time.Duration {
+	if s.cfg.EnrichmentTTL > 0 {
+		return s.cfg.EnrichmentTTL
+	}
+	return defaultEnrichmentTTL
+}
+
+// EnrichJiras looks up every jira with an empty mapped field in Jira, caches
+// the result and copies it into the fields that are still empty. It returns
+// how many jiras were looked up. A rate limit from Jira ends the pass early
+// without error; the remaining jiras are picked up by the next pass. Any other
+// failed lookup is recorded and retried after enrichRetryInterval, so one
+// broken issue does not hold up the ones after it.
+func (s *svc) EnrichJiras(ctx context.Context) (int, error) {
+	if s.jira == nil {
+		return 0, nil
+	}
+	fields := s.jira.Fields()
+
+	total := 0
+	for {
+		now := time.Now()
+		ids, err := s.q.ListJirasToEnrich(ctx, db.ListJirasToEnrichParams{
+			WantTitle:    fields.Title != "",
+			WantImpact:   fields.Impact != "",
+			WantDomain:   fields.Domain != "",
+			WantRelnotes: fields.Relnotes != "",
+			StaleBefore:  pgtype.Timestamptz{Time: now.Add(-s.enrichmentTTL()), Valid: true},
+			RetryBefore:  pgtype.Timestamptz{Time: now.Add(-min(s.enrichmentTTL(), enrichRetryInterval)), Valid: true},
+			BatchSize:    enrichBatchSize,
+		})
+		if err != nil {
+			return total, fmt.Errorf("list jiras to enrich: %w", err)
+		}
+
+		for _, id := range ids {
+			issue, err := s.jira.Issue(ctx, id)
+			var rle *jiraapi.RateLimitError
+			switch {
+			case errors.As(err, &rle):
+				s.log.Warn("jira rate limit hit, pausing enrichment", "retry_after", rle.RetryAfter, "enriched", total)
+				return total, nil
+			case ctx.Err() != nil:
+				return total, ctx.Err()
+			case errors.Is(err, jiraapi.ErrNotFound):
+				// Cache the miss so unknown ids are not looked up on every pass.
+				issue = &jiraapi.Issue{Key: id}
+			case err != nil:
+				s.log.Warn("jira lookup failed", "jira", id, "error", err)
+				if err := s.q.MarkJiraEnrichmentFailed(ctx, id); err != nil {
+					return total, fmt.Errorf("mark jira %s failed: %w", id, err)
+				}
+				total++
+				continue
+			}
+			found := err == nil
+
+			if err := s.q.UpsertJiraEnrichment(ctx, db.UpsertJiraEnrichmentParams{
+				JiraID:   id,
+				Title:    issue.Title,
+				Impact:   issue.Impact,
+				Domain:   issue.Domain,
+				Relnotes: issue.Relnotes,
+				Found:    found,
+			}); err != nil {
+				return total, fmt.Errorf("cache jira %s: %w", id, err)
+			}
+			if found {
+				if err := s.fillJira(ctx, id); err != nil {
+					return total, err
+				}
+			}
+			total++
+		}
+
+		if len(ids) < enrichBatchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		s.log.Info("jiras enriched", "count", total)
+	}
+	return total, nil
+}
+
+// fillJira copies the cached lookup for id into the jira's empty fields and
+// records the result as a revision with no release, so as-of diffs pick up
+// enriched metadata like a PATCH.
+func (s *svc) fillJira(ctx context.Context, id string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	j, err := qtx.FillJiraFromEnrichment(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("enrich jira %s: %w", id, err)
+	}
+	if err := insertJiraRevisions(ctx, qtx, "", []JiraInput{{
+		ID:       j.ID,
+		Title:    j.Title,
+		Impact:   j.Impact,
+		Domain:   j.Domain,
+		Relnotes: j.Relnotes,
+	}}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// scheduleEnrichment runs EnrichJiras in the background after a write. At most
+// one background pass runs at a time; a write that lands while one is running
+// makes it go round again. Passes stop when the service is closed.
+func (s *svc) scheduleEnrichment() {
+	if s.jira == nil || s.ctx.Err() != nil {
+		return
+	}
+	s.enrichPending.Store(true)
+	if s.enrichRunning.CompareAndSwap(false, true) {
+		s.bg.Add(1)
+		go func() {
+			defer s.bg.Done()
+			s.runEnrichment()
+		}()
+	}
+}
+
+func (s *svc) runEnrichment() {
+	for {
+		for s.enrichPending.Swap(false) {
+			if _, err := s.EnrichJiras(s.ctx); err != nil && s.ctx.Err() == nil {
+				s.log.Error("jira enrichment failed", "error", err)
+			}
+		}
+		s.enrichRunning.Store(false)
+		// A write may have set pending after the last check but before
+		// running was cleared; take that work on unless another pass did.
+		if !s.enrichPending.Load() || !s.enrichRunning.CompareAndSwap(false, true) {
+			return
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import "errors"
+
+// ErrNotFound is returned when a requested release does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrPreconditionFailed is returned when an If-Match precondition does not
+// match the current state of a release.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ErrIdempotencyConflict is returned when an idempotency key is reused with a
+// different request payload.
+var ErrIdempotencyConflict = errors.New("idempotency key reused with a different payload")
+
+// ErrReleaseLocked is returned when a frozen release would be changed without
+// an admin override.
+var ErrReleaseLocked = errors.New("release is locked")
+
+// ErrUnauthorized is returned when a request carries no valid API token.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is returned when the caller's role does not allow a change.
+var ErrForbidden = errors.New("forbidden")
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"jiraiya/internal/db"
+)
+
+// releaseETag derives a strong entity tag from a release's last update time
+// and the set of jiras linked to it.
+func releaseETag(updatedAt time.Time, jiraIDs []string) string {
+	ids := make([]string, len(jiraIDs))
+	copy(ids, jiraIDs)
+	sort.Strings(ids)
+
+	h := sha256.New()
+	h.Write([]byte(updatedAt.UTC().Format(time.RFC3339Nano)))
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+		h.Write([]byte{0})
+		h.Write([]byte(id))
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// etagMatches reports whether an If-Match header value matches etag. An empty
+// etag means the release does not exist, which only an empty header matches.
+func etagMatches(ifMatch, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// lockRelease locks the release row for the rest of the transaction and
+// verifies ifMatch against its current ETag. An empty ifMatch skips the check
+// but still takes the lock so concurrent writes serialize. exists is false if
+// the release is not stored yet.
+func lockRelease(ctx context.Context, qtx *db.Queries, version, ifMatch string) (rel db.Release, exists bool, err error) {
+	rel, err = qtx.LockRelease(ctx, version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if ifMatch != "" {
+			return rel, false, ErrPreconditionFailed
+		}
+		return rel, false, nil
+	}
+	if err != nil {
+		return rel, false, fmt.Errorf("lock release %s: %w", version, err)
+	}
+	if rel.DeletedAt.Valid {
+		// A soft-deleted release only exists for restore and purge.
+		if ifMatch != "" {
+			return rel, false, ErrPreconditionFailed
+		}
+		return rel, false, nil
+	}
+	if ifMatch == "" {
+		return rel, true, nil
+	}
+
+	ids, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return rel, true, fmt.Errorf("get jiras for etag: %w", err)
+	}
+	if !etagMatches(ifMatch, releaseETag(rel.UpdatedAt.Time, ids)) {
+		return rel, true, ErrPreconditionFailed
+	}
+	return rel, true, nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"jiraiya/internal/db"
+	"jiraiya/internal/releasetree"
+)
+
+// defaultFeedLimit is used when FeedQuery.Limit is zero.
+const defaultFeedLimit = 20
+
+func (s *svc) GetFeed(ctx context.Context, platform string, fq FeedQuery) (*Feed, error) {
+	if fq.Limit == 0 {
+		fq.Limit = defaultFeedLimit
+	}
+	limit, err := pageBounds(fq.Limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := requirePlatformRole(ctx, platform, RoleReader); err != nil {
+		return nil, err
+	}
+
+	rels, err := s.q.GetAllReleasesByPlatform(ctx, platform)
+	if err != nil {
+		return nil, fmt.Errorf("get releases by platform: %w", err)
+	}
+
+	if fq.Branch != "" {
+		branch, err := s.branchVersions(ctx, platform, fq.Branch)
+		if err != nil {
+			return nil, err
+		}
+		kept := rels[:0]
+		for _, r := range rels {
+			if branch[r.Version] {
+				kept = append(kept, r)
+			}
+		}
+		rels = kept
+	}
+
+	// Newest first; version keeps the order stable for equal timestamps.
+	sort.Slice(rels, func(i, j int) bool {
+		if !rels[i].UpdatedAt.Time.Equal(rels[j].UpdatedAt.Time) {
+			return rels[i].UpdatedAt.Time.After(rels[j].UpdatedAt.Time)
+		}
+		return rels[i].Version > rels[j].Version
+	})
+	if len(rels) > limit {
+		rels = rels[:limit]
+	}
+
+	feed := &Feed{Platform: platform, Entries: make([]FeedEntry, len(rels))}
+	added := make([][]releasetree.Chg, len(rels))
+	var ids []string
+	for i, r := range rels {
+		if added[i], err = s.addedChanges(platform, r); err != nil {
+			return nil, err
+		}
+		for _, c := range added[i] {
+			ids = append(ids, c.ID)
+		}
+		if r.UpdatedAt.Time.After(feed.Updated) {
+			feed.Updated = r.UpdatedAt.Time
+		}
+	}
+
+	jiras, err := s.q.GetJirasByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras by ids: %w", err)
+	}
+	byID := make(map[string]db.Jira, len(jiras))
+	for _, j := range jiras {
+		byID[j.ID] = j
+	}
+
+	for i, r := range rels {
+		entry := FeedEntry{
+			Version:     r.Version,
+			FromVer:     r.FromVer,
+			ReleaseDate: formatDate(r.ReleaseDate),
+			SubmittedBy: r.SubmittedBy,
+			Status:      r.Status,
+			Updated:     r.UpdatedAt.Time,
+			Jiras:       make([]JiraOutput, 0, len(added[i])),
+		}
+		for _, c := range added[i] {
+			if j, ok := byID[c.ID]; ok {
+				entry.Jiras = append(entry.Jiras, jiraOutput(j))
+			}
+		}
+		feed.Entries[i] = entry
+	}
+	return feed, nil
+}
+
+// branchVersions returns the set of versions in the subtree rooted at branch,
+// including branch itself.
+func (s *svc) branchVersions(ctx context.Context, platform, branch string) (map[string]bool, error) {
+	rel, err := s.getRelease(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	if rel.Platform != platform {
+		return nil, ErrNotFound
+	}
+	dump, err := s.tm.DumpSubtree(platform, branch, 0)
+	if err != nil {
+		return nil, fmt.Errorf("dump branch %s: %w", branch, err)
+	}
+	out := make(map[string]bool, len(dump.Nodes))
+	for _, n := range dump.Nodes {
+		out[n.Version] = true
+	}
+	return out, nil
+}
+
+// addedChanges returns the changes a release added over its parent. A root
+// release added everything it ships.
+func (s *svc) addedChanges(platform string, r db.Release) ([]releasetree.Chg, error) {
+	if r.FromVer == "" {
+		chgs, err := s.tm.Cumulative(platform, r.Version)
+		if err != nil {
+			return nil, fmt.Errorf("cumulative changes for %s: %w", r.Version, err)
+		}
+		return chgs, nil
+	}
+	chgs, err := s.tm.CalcChgs(platform, r.Version, r.FromVer)
+	if err != nil {
+		return nil, fmt.Errorf("calc changes for %s: %w", r.Version, err)
+	}
+	return chgs, nil
+}
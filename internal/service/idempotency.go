@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"jiraiya/internal/db"
+)
+
+// defaultIdempotencyRetention is used when Config.IdempotencyRetention is zero.
+const defaultIdempotencyRetention = 24 * time.Hour
+
+// storedResult is the persisted form of a SubmitResult.
+type storedResult struct {
+	ETag     string             `json:"etag"`
+	Warnings []MetadataConflict `json:"warnings,omitempty"`
+}
+
+// hashSubmission returns a stable fingerprint of a submission payload so a
+// reused idempotency key can be checked against the original request.
+func hashSubmission(sub ReleaseSubmission) (string, error) {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return "", fmt.Errorf("marshal submission: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// replayIdempotent returns the stored result for key, or nil if the key is
+// unknown or has expired. A key reused with a different payload yields
+// ErrIdempotencyConflict.
+func (s *svc) replayIdempotent(ctx context.Context, key, hash string) (*SubmitResult, error) {
+	row, err := s.q.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		Key:           key,
+		ExpiredBefore: s.idempotencyCutoff(),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get idempotency key: %w", err)
+	}
+	if row.RequestHash != hash {
+		return nil, ErrIdempotencyConflict
+	}
+
+	var stored storedResult
+	if err := json.Unmarshal(row.Response, &stored); err != nil {
+		return nil, fmt.Errorf("decode stored response: %w", err)
+	}
+	return &SubmitResult{ETag: stored.ETag, Warnings: stored.Warnings, Replayed: true}, nil
+}
+
+// storeIdempotent records res under key inside the submission transaction. It
+// reports false if another request committed the same key first.
+func (s *svc) storeIdempotent(ctx context.Context, qtx *db.Queries, key, hash string, res *SubmitResult) (bool, error) {
+	data, err := json.Marshal(storedResult{ETag: res.ETag, Warnings: res.Warnings})
+	if err != nil {
+		return false, fmt.Errorf("marshal stored response: %w", err)
+	}
+	n, err := qtx.InsertIdempotencyKey(ctx, db.InsertIdempotencyKeyParams{
+		Key:           key,
+		RequestHash:   hash,
+		Response:      data,
+		ExpiredBefore: s.idempotencyCutoff(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("insert idempotency key: %w", err)
+	}
+	return n == 1, nil
+}
+
+func (s *svc) idempotencyRetention() time.Duration {
+	if s.cfg.IdempotencyRetention > 0 {
+		return s.cfg.IdempotencyRetention
+	}
+	return defaultIdempotencyRetention
+}
+
+// idempotencyCutoff is the creation time before which a key has expired.
+func (s *svc) idempotencyCutoff() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().Add(-s.idempotencyRetention()), Valid: true}
+}
+
+func (s *svc) PurgeIdempotencyKeys(ctx context.Context) (int, error) {
+	n, err := s.q.DeleteExpiredIdempotencyKeys(ctx, s.idempotencyCutoff())
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	if n > 0 {
+		s.log.Info("idempotency keys purged", "count", n)
+	}
+	return int(n), nil
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+
+	"jiraiya/internal/db"
+	"jiraiya/internal/releasetree"
 )
 
 func (s *svc) GetReleases(ctx context.Context, version, platform string) ([]ReleaseOutput, error) {
@@ -11,33 +14,79 @@ func (s *svc) GetReleases(ctx context.Context, version, platform string) ([]Rele
 		if err != nil {
 			return nil, fmt.Errorf("get release %s: %w", version, err)
 		}
-		return []ReleaseOutput{{
-			Version:     r.Version,
-			FromVer:     r.FromVer,
-			Platform:    r.Platform,
-			ReleaseDate: r.ReleaseDate,
-			SubmittedBy: r.SubmittedBy,
-		}}, nil
+		if err := requirePlatformRole(ctx, r.Platform, RoleReader); err != nil {
+			return nil, err
+		}
+		ids, err := s.q.GetJiraIDsByRelease(ctx, version)
+		if err != nil {
+			return nil, fmt.Errorf("get jiras for %s: %w", version, err)
+		}
+		out := s.releaseOutput(r)
+		out.ETag = releaseETag(r.UpdatedAt.Time, ids)
+		return []ReleaseOutput{out}, nil
 	}
 
+	if err := requirePlatformRole(ctx, platform, RoleReader); err != nil {
+		return nil, err
+	}
 	rows, err := s.q.GetAllReleasesByPlatform(ctx, platform)
 	if err != nil {
 		return nil, fmt.Errorf("get releases by platform: %w", err)
 	}
 	out := make([]ReleaseOutput, len(rows))
 	for i, r := range rows {
-		out[i] = ReleaseOutput{
-			Version:     r.Version,
-			FromVer:     r.FromVer,
-			Platform:    r.Platform,
-			ReleaseDate: r.ReleaseDate,
-			SubmittedBy: r.SubmittedBy,
-		}
+		out[i] = s.releaseOutput(r)
 	}
 	return out, nil
 }
 
+func (s *svc) ListReleases(ctx context.Context, platform string, lq ListQuery) (*ReleaseList, error) {
+	ks, err := parseListQuery(lq, "version", orderAsc)
+	if err != nil {
+		return nil, err
+	}
+	if err := requirePlatformRole(ctx, platform, RoleReader); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.listReleasePage(ctx, platform, ks, releaseFilter{statuses: []string{}})
+	if err != nil {
+		return nil, fmt.Errorf("list releases: %w", err)
+	}
+	total, err := s.q.CountReleasesByPlatform(ctx, platform)
+	if err != nil {
+		return nil, fmt.Errorf("count releases: %w", err)
+	}
+
+	list := &ReleaseList{Total: total}
+	if len(rows) > ks.limit {
+		rows = rows[:ks.limit]
+		list.NextCursor = ks.next(rows[len(rows)-1])
+	}
+	list.Releases = make([]ReleaseOutput, len(rows))
+	for i, r := range rows {
+		list.Releases[i] = s.releaseOutput(r)
+	}
+	return list, nil
+}
+
+// releaseOutput converts a stored release to its client representation.
+func (s *svc) releaseOutput(r db.Release) ReleaseOutput {
+	return ReleaseOutput{
+		Version:     r.Version,
+		FromVer:     r.FromVer,
+		Platform:    r.Platform,
+		ReleaseDate: formatDate(r.ReleaseDate),
+		SubmittedBy: r.SubmittedBy,
+		Status:      r.Status,
+		Locked:      s.isLocked(r),
+	}
+}
+
 func (s *svc) GetFilters(ctx context.Context, platform string) (*Filters, error) {
+	if err := requirePlatformRole(ctx, platform, RoleReader); err != nil {
+		return nil, err
+	}
 	domains, err := s.q.GetDistinctDomains(ctx, platform)
 	if err != nil {
 		return nil, fmt.Errorf("get domains: %w", err)
@@ -55,31 +104,70 @@ func (s *svc) GetFilters(ctx context.Context, platform string) (*Filters, error)
 	return &Filters{Domains: domains, Impacts: impacts}, nil
 }
 
-func (s *svc) GetVersions(ctx context.Context, platform string) ([]VersionInfo, error) {
-	rows, err := s.q.GetVersionsByPlatform(ctx, platform)
+func (s *svc) GetVersions(ctx context.Context, platform string, vq VersionQuery) (*VersionList, error) {
+	ks, err := parseListQuery(vq.ListQuery, "release_date", orderDesc)
+	if err != nil {
+		return nil, err
+	}
+	if err := requirePlatformRole(ctx, platform, RoleReader); err != nil {
+		return nil, err
+	}
+
+	f := releaseFilter{since: toDate(vq.Since), until: toDate(vq.Until), statuses: vq.Statuses}
+	if f.statuses == nil {
+		// pgx sends a nil slice as NULL, which matches no status.
+		f.statuses = []string{}
+	}
+	rows, err := s.listReleasePage(ctx, platform, ks, f)
 	if err != nil {
 		return nil, fmt.Errorf("get versions: %w", err)
 	}
-	versions := make([]VersionInfo, len(rows))
+	total, err := s.q.CountVersionsByPlatform(ctx, db.CountVersionsByPlatformParams{
+		Platform: platform,
+		Since:    f.since,
+		Until:    f.until,
+		Statuses: f.statuses,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count versions: %w", err)
+	}
+
+	list := &VersionList{Total: total}
+	if len(rows) > ks.limit {
+		rows = rows[:ks.limit]
+		list.NextCursor = ks.next(rows[len(rows)-1])
+	}
+	list.Versions = make([]VersionInfo, len(rows))
 	for i, r := range rows {
-		versions[i] = VersionInfo{
+		list.Versions[i] = VersionInfo{
 			Version:     r.Version,
 			FromVer:     r.FromVer,
-			ReleaseDate: r.ReleaseDate,
+			ReleaseDate: formatDate(r.ReleaseDate),
 			SubmittedBy: r.SubmittedBy,
+			Status:      r.Status,
 		}
 	}
-	return versions, nil
+	return list, nil
 }
 
-func (s *svc) GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string) ([]JiraOutput, error) {
+func (s *svc) GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string, opts DiffOptions) ([]JiraOutput, error) {
 	// Look up "to" release to determine platform
 	rel, err := s.q.GetRelease(ctx, toVer)
 	if err != nil {
 		return nil, fmt.Errorf("get release %s: %w", toVer, err)
 	}
+	if err := requirePlatformRole(ctx, rel.Platform, RoleReader); err != nil {
+		return nil, err
+	}
 
-	chgs, err := s.tm.CalcChgs(rel.Platform, toVer, fromVer)
+	var skip func(string) bool
+	if opts.GAOnly {
+		if skip, err = s.nonGASkipper(ctx, rel.Platform); err != nil {
+			return nil, err
+		}
+	}
+
+	chgs, err := s.tm.CalcChgsAttributed(rel.Platform, toVer, fromVer, skip)
 	if err != nil {
 		return nil, fmt.Errorf("calc changes: %w", err)
 	}
@@ -89,8 +177,10 @@ func (s *svc) GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string
 	}
 
 	ids := make([]string, len(chgs))
+	introducedIn := make(map[string]string, len(chgs))
 	for i, c := range chgs {
 		ids[i] = c.ID
+		introducedIn[c.ID] = c.Version
 	}
 
 	jiras, err := s.q.GetJirasByIDs(ctx, ids)
@@ -100,19 +190,43 @@ func (s *svc) GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string
 
 	out := make([]JiraOutput, len(jiras))
 	for i, j := range jiras {
-		out[i] = JiraOutput{
-			ID:       j.ID,
-			Title:    j.Title,
-			Impact:   j.Impact,
-			Domain:   j.Domain,
-			Relnotes: j.Relnotes,
+		out[i] = jiraOutput(j)
+		out[i].Release = introducedIn[j.ID]
+	}
+
+	if opts.AsOfTo {
+		if err := s.applyRevisionsAsOf(ctx, out, ids, rel.UpdatedAt); err != nil {
+			return nil, err
 		}
 	}
 	return out, nil
 }
 
-func (s *svc) GetTreeInfo(ctx context.Context, platform string) (*TreeInfo, error) {
-	dump, err := s.tm.Dump(platform)
+func (s *svc) GetTreeInfo(ctx context.Context, platform string, tq TreeQuery) (*TreeInfo, error) {
+	if tq.Depth < 0 {
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: "depth must not be negative"}}}
+	}
+	if err := requirePlatformRole(ctx, platform, RoleReader); err != nil {
+		return nil, err
+	}
+
+	if tq.Root != "" {
+		rel, err := s.getRelease(ctx, tq.Root)
+		if err != nil {
+			return nil, err
+		}
+		if rel.Platform != platform {
+			return nil, ErrNotFound
+		}
+	}
+
+	var dump *releasetree.TreeDump
+	var err error
+	if tq.Root == "" && tq.Depth == 0 {
+		dump, err = s.tm.Dump(platform)
+	} else {
+		dump, err = s.tm.DumpSubtree(platform, tq.Root, tq.Depth)
+	}
 	if err != nil {
 		return nil, err
 	}
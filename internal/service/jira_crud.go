@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"jiraiya/internal/db"
+)
+
+// Page size bounds for SearchJiras.
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// jiraOutput converts a stored jira to its client representation.
+func jiraOutput(j db.Jira) JiraOutput {
+	return JiraOutput{
+		ID:       j.ID,
+		Title:    j.Title,
+		Impact:   j.Impact,
+		Domain:   j.Domain,
+		Relnotes: j.Relnotes,
+	}
+}
+
+func (s *svc) GetJira(ctx context.Context, id string) (*JiraDetail, error) {
+	j, err := s.q.GetJira(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get jira %s: %w", id, err)
+	}
+	// Jira metadata is shared, but the releases that link it are only
+	// listed on platforms the caller can read.
+	platforms, all := grantedPlatforms(ctx, RoleReader)
+	releases, err := s.q.GetReleaseVersionsByJira(ctx, db.GetReleaseVersionsByJiraParams{
+		JiraID:       id,
+		AllPlatforms: all,
+		Platforms:    platforms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get releases for %s: %w", id, err)
+	}
+	if releases == nil {
+		releases = []string{}
+	}
+	return &JiraDetail{JiraOutput: jiraOutput(j), Releases: releases}, nil
+}
+
+// pageBounds validates a requested page and applies the default limit.
+func pageBounds(limit, offset int) (int, error) {
+	if limit < 0 || limit > maxPageLimit {
+		return 0, &ValidationError{Details: []ValidationDetail{{Reason: fmt.Sprintf("limit must be between 1 and %d", maxPageLimit)}}}
+	}
+	if offset < 0 {
+		return 0, &ValidationError{Details: []ValidationDetail{{Reason: "offset must not be negative"}}}
+	}
+	if limit == 0 {
+		return defaultPageLimit, nil
+	}
+	return limit, nil
+}
+
+func (s *svc) SearchJiras(ctx context.Context, js JiraSearch) (*JiraPage, error) {
+	var err error
+	if js.Limit, err = pageBounds(js.Limit, js.Offset); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.q.SearchJiras(ctx, db.SearchJirasParams{
+		Query:      js.Query,
+		Domain:     js.Domain,
+		Impact:     js.Impact,
+		PageLimit:  int32(js.Limit),
+		PageOffset: int32(js.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search jiras: %w", err)
+	}
+	total, err := s.q.CountJiras(ctx, db.CountJirasParams{
+		Query:  js.Query,
+		Domain: js.Domain,
+		Impact: js.Impact,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("count jiras: %w", err)
+	}
+
+	page := &JiraPage{Jiras: make([]JiraOutput, len(rows)), Total: total, Limit: js.Limit, Offset: js.Offset}
+	for i, j := range rows {
+		page.Jiras[i] = jiraOutput(j)
+	}
+	return page, nil
+}
+
+func (s *svc) UpdateJira(ctx context.Context, id string, patch JiraPatch) (*JiraOutput, error) {
+	if patch.Title == nil && patch.Impact == nil && patch.Domain == nil && patch.Relnotes == nil {
+		return nil, &ValidationError{Details: []ValidationDetail{{ID: id, Reason: "at least one field is required"}}}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	j, err := qtx.UpdateJira(ctx, db.UpdateJiraParams{
+		Title:    optionalText(patch.Title),
+		Impact:   optionalText(patch.Impact),
+		Domain:   optionalText(patch.Domain),
+		Relnotes: optionalText(patch.Relnotes),
+		ID:       id,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update jira %s: %w", id, err)
+	}
+
+	// Record the edit as a revision with no release, so as-of diffs pick it
+	// up from now on.
+	if err := insertJiraRevisions(ctx, qtx, "", []JiraInput{{
+		ID:       j.ID,
+		Title:    j.Title,
+		Impact:   j.Impact,
+		Domain:   j.Domain,
+		Relnotes: j.Relnotes,
+	}}); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	s.log.Info("jira updated", "jira_id", id, "actor", actorFrom(ctx, ""))
+	out := jiraOutput(j)
+	return &out, nil
+}
+
+// optionalText maps a nil pointer to SQL NULL.
+func optionalText(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jiraiya/internal/db"
+)
+
+// Audit actions recorded when a release is frozen or unfrozen.
+const (
+	auditLock   = "lock"
+	auditUnlock = "unlock"
+)
+
+// isLocked reports whether a release is frozen, either by its manual flag or
+// because Config.AutoLockDays have passed since its release date.
+func (s *svc) isLocked(r db.Release) bool {
+	if r.Locked {
+		return true
+	}
+	if s.cfg.AutoLockDays <= 0 || !r.ReleaseDate.Valid {
+		return false
+	}
+	return !time.Now().Before(r.ReleaseDate.Time.AddDate(0, 0, s.cfg.AutoLockDays))
+}
+
+// checkLocked rejects a change to a frozen release unless the caller supplied
+// an admin override, which is logged with its reason. Only admins of the
+// release's platform may override.
+func (s *svc) checkLocked(ctx context.Context, r db.Release, action string, opts WriteOptions) error {
+	if !s.isLocked(r) {
+		return nil
+	}
+	if opts.Override == "" {
+		return ErrReleaseLocked
+	}
+	if err := requirePlatformRole(ctx, r.Platform, RoleAdmin); err != nil {
+		return err
+	}
+	s.log.Warn("locked release override",
+		"version", r.Version,
+		"platform", r.Platform,
+		"action", action,
+		"actor", actorFrom(ctx, ""),
+		"reason", opts.Override,
+	)
+	return nil
+}
+
+func (s *svc) SetReleaseLock(ctx context.Context, version string, locked bool, opts WriteOptions) (*ReleaseOutput, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	rel, exists, err := lockRelease(ctx, qtx, version, opts.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if err := requirePlatformRole(ctx, rel.Platform, RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	action := auditLock
+	if !locked {
+		if !rel.Locked && s.isLocked(rel) {
+			// Clearing the manual flag would leave the release frozen.
+			return nil, &ValidationError{Details: []ValidationDetail{{ID: version, Reason: "release is auto-locked by its release date"}}}
+		}
+		// Unfreezing is itself a change to a frozen release.
+		action = auditUnlock
+		if err := s.checkLocked(ctx, rel, action, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := qtx.SetReleaseLocked(ctx, db.SetReleaseLockedParams{Version: version, Locked: locked}); err != nil {
+		return nil, fmt.Errorf("set locked: %w", err)
+	}
+	if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+		ReleaseVersion: version,
+		Platform:       rel.Platform,
+		Action:         action,
+		Actor:          actorFrom(ctx, ""),
+		AddedJiras:     []string{},
+		RemovedJiras:   []string{},
+	}); err != nil {
+		return nil, fmt.Errorf("insert audit: %w", err)
+	}
+
+	updated, err := qtx.GetRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", version, err)
+	}
+	ids, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras for %s: %w", version, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	s.log.Info("release lock changed", "version", version, "locked", locked, "actor", actorFrom(ctx, ""))
+	out := s.releaseOutput(updated)
+	out.ETag = releaseETag(updated.UpdatedAt.Time, ids)
+	return &out, nil
+}
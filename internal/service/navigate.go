@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"jiraiya/internal/db"
+)
+
+// The lookups below serve clients that walk the release tree a level at a
+// time. The batch methods take every key a level needs so a walk costs one
+// query per level rather than one per release or jira.
+
+func (s *svc) ListPlatforms(ctx context.Context) ([]string, error) {
+	platforms, err := s.q.GetAllPlatforms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get platforms: %w", err)
+	}
+	out := []string{}
+	for _, p := range platforms {
+		if requirePlatformRole(ctx, p, RoleReader) == nil {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// GetReleaseNode reads the release's node from the in-memory tree, so it
+// costs no query. It returns ErrNotFound if the platform has no such release.
+func (s *svc) GetReleaseNode(ctx context.Context, platform, version string) (*ReleaseNode, error) {
+	if err := requirePlatformRole(ctx, platform, RoleReader); err != nil {
+		return nil, err
+	}
+	n, err := s.tm.Node(platform, version)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	ancestors, err := s.tm.Ancestors(platform, version)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	return &ReleaseNode{
+		Version:   n.Version,
+		Parent:    n.FromVer,
+		Children:  n.Children,
+		Ancestors: ancestors,
+		Jiras:     n.Changes,
+	}, nil
+}
+
+// GetReleasesByVersions returns the releases that exist among versions, in no
+// particular order. Missing and deleted versions are skipped, as are releases
+// on platforms the caller cannot read.
+func (s *svc) GetReleasesByVersions(ctx context.Context, versions []string) ([]ReleaseOutput, error) {
+	rows, err := s.q.GetReleasesByVersions(ctx, versions)
+	if err != nil {
+		return nil, fmt.Errorf("get releases by versions: %w", err)
+	}
+	out := make([]ReleaseOutput, 0, len(rows))
+	for _, r := range rows {
+		if requirePlatformRole(ctx, r.Platform, RoleReader) == nil {
+			out = append(out, s.releaseOutput(r))
+		}
+	}
+	return out, nil
+}
+
+// GetJirasByIDs returns the jiras that exist among ids, in no particular
+// order.
+func (s *svc) GetJirasByIDs(ctx context.Context, ids []string) ([]JiraOutput, error) {
+	rows, err := s.q.GetJirasByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras by ids: %w", err)
+	}
+	out := make([]JiraOutput, len(rows))
+	for i, j := range rows {
+		out[i] = jiraOutput(j)
+	}
+	return out, nil
+}
+
+// GetReleaseVersionsByJiras maps each of ids to the versions that link it,
+// sorted. Only releases the caller can read are listed; jiras no such
+// release links are left out of the map.
+func (s *svc) GetReleaseVersionsByJiras(ctx context.Context, ids []string) (map[string][]string, error) {
+	platforms, all := grantedPlatforms(ctx, RoleReader)
+	rows, err := s.q.GetReleaseVersionsByJiras(ctx, db.GetReleaseVersionsByJirasParams{
+		Ids:          ids,
+		AllPlatforms: all,
+		Platforms:    platforms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get releases by jiras: %w", err)
+	}
+	out := make(map[string][]string)
+	for _, r := range rows {
+		out[r.JiraID] = append(out[r.JiraID], r.Version)
+	}
+	return out, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"jiraiya/internal/db"
@@ -24,14 +25,30 @@ func (e *ValidationError) Error() string {
 	return "validation failed"
 }
 
-func (s *svc) SubmitRelease(ctx context.Context, sub ReleaseSubmission) error {
+func (s *svc) SubmitRelease(ctx context.Context, sub ReleaseSubmission, opts WriteOptions) (*SubmitResult, error) {
+	// The authenticated caller is the submitter, whatever the body claims
+	if id := IdentityFrom(ctx); id != nil {
+		sub.Release.SubmittedBy = id.Name
+	}
+
 	// Validate release
 	r := sub.Release
 	if r.Version == "" {
-		return &ValidationError{Details: []ValidationDetail{{Reason: "release version is required"}}}
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: "release version is required"}}}
 	}
 	if r.Platform == "" {
-		return &ValidationError{Details: []ValidationDetail{{Reason: "release platform is required"}}}
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: "release platform is required"}}}
+	}
+	if err := requirePlatformRole(ctx, r.Platform, RoleSubmitter); err != nil {
+		return nil, err
+	}
+
+	releaseDate, err := parseDate(r.ReleaseDate)
+	if err != nil {
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: "release_date must be a valid YYYY-MM-DD date"}}}
+	}
+	if r.Status != "" && !validStatus(r.Status) {
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: fmt.Sprintf("unknown status %q", r.Status)}}}
 	}
 
 	// Validate jiras
@@ -42,18 +59,75 @@ func (s *svc) SubmitRelease(ctx context.Context, sub ReleaseSubmission) error {
 		}
 	}
 	if len(details) > 0 {
-		return &ValidationError{Details: details}
+		return nil, &ValidationError{Details: details}
+	}
+
+	// Replay a previously completed submission with the same key
+	var reqHash string
+	if opts.IdempotencyKey != "" {
+		h, err := hashSubmission(sub)
+		if err != nil {
+			return nil, err
+		}
+		reqHash = h
+		res, err := s.replayIdempotent(ctx, opts.IdempotencyKey, reqHash)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			s.log.Info("release submission replayed", "version", r.Version, "idempotency_key", opts.IdempotencyKey)
+			return res, nil
+		}
 	}
 
 	// Begin transaction
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("begin tx: %w", err)
+		return nil, fmt.Errorf("begin tx: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
 	qtx := s.q.WithTx(tx)
 
+	// Lock the release and enforce If-Match
+	existing, exists, err := lockRelease(ctx, qtx, r.Version, opts.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		// Moving a release to another platform also takes a grant on the
+		// platform it leaves.
+		if existing.Platform != r.Platform {
+			if err := requirePlatformRole(ctx, existing.Platform, RoleSubmitter); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkLocked(ctx, existing, auditSubmit, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	// Status is only set on creation; later changes go through transitions
+	status := r.Status
+	if exists {
+		if status != "" && status != existing.Status {
+			return nil, &ValidationError{Details: []ValidationDetail{{Reason: "status of an existing release can only be changed with a transition"}}}
+		}
+		status = existing.Status
+	} else if status == "" {
+		status = StatusGA
+	}
+
+	// Detect metadata changes before the upsert overwrites them
+	conflicts, revised, err := compareJiras(ctx, qtx, sub.Changes)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conflicts {
+		s.log.Warn("jira metadata overwritten", "version", r.Version, "jira_id", c.JiraID, "field", c.Field)
+	}
+
 	// Upsert each jira
 	for _, j := range sub.Changes {
 		if err := qtx.UpsertJira(ctx, db.UpsertJiraParams{
@@ -63,36 +137,107 @@ func (s *svc) SubmitRelease(ctx context.Context, sub ReleaseSubmission) error {
 			Domain:   j.Domain,
 			Relnotes: j.Relnotes,
 		}); err != nil {
-			return fmt.Errorf("upsert jira %s: %w", j.ID, err)
+			return nil, fmt.Errorf("upsert jira %s: %w", j.ID, err)
 		}
 	}
+	if err := insertJiraRevisions(ctx, qtx, r.Version, revised); err != nil {
+		return nil, err
+	}
 
 	// Upsert release
 	if err := qtx.UpsertRelease(ctx, db.UpsertReleaseParams{
 		Version:     r.Version,
 		FromVer:     r.FromVer,
 		Platform:    r.Platform,
-		ReleaseDate: r.ReleaseDate,
+		ReleaseDate: releaseDate,
 		SubmittedBy: r.SubmittedBy,
+		Status:      status,
 	}); err != nil {
-		return fmt.Errorf("upsert release: %w", err)
+		return nil, fmt.Errorf("upsert release: %w", err)
+	}
+
+	// Capture the current links for the audit diff before replacing them. A
+	// soft-deleted release keeps its links, but they no longer count.
+	var before []string
+	if exists {
+		before, err = qtx.GetJiraIDsByRelease(ctx, r.Version)
+		if err != nil {
+			return nil, fmt.Errorf("get current jiras: %w", err)
+		}
 	}
 
 	// Unlink old jiras, re-link new ones
 	if err := qtx.UnlinkJirasFromRelease(ctx, r.Version); err != nil {
-		return fmt.Errorf("unlink jiras: %w", err)
+		return nil, fmt.Errorf("unlink jiras: %w", err)
 	}
 	for _, j := range sub.Changes {
 		if err := qtx.LinkJiraToRelease(ctx, db.LinkJiraToReleaseParams{
 			ReleaseVersion: r.Version,
 			JiraID:         j.ID,
 		}); err != nil {
-			return fmt.Errorf("link jira %s: %w", j.ID, err)
+			return nil, fmt.Errorf("link jira %s: %w", j.ID, err)
+		}
+	}
+
+	// Record the submission in the audit log
+	payload, err := json.Marshal(sub)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit payload: %w", err)
+	}
+	after := make([]string, len(sub.Changes))
+	for i, j := range sub.Changes {
+		after[i] = j.ID
+	}
+	added, removed := diffJiraIDs(before, after)
+	if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+		ReleaseVersion: r.Version,
+		Platform:       r.Platform,
+		Action:         auditSubmit,
+		Actor:          actorFrom(ctx, r.SubmittedBy),
+		Payload:        payload,
+		AddedJiras:     added,
+		RemovedJiras:   removed,
+	}); err != nil {
+		return nil, fmt.Errorf("insert audit: %w", err)
+	}
+
+	updated, err := qtx.GetRelease(ctx, r.Version)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", r.Version, err)
+	}
+
+	event := EventReleaseSubmitted
+	if exists {
+		event = EventReleaseUpdated
+	}
+	if err := s.enqueueReleaseEvent(ctx, qtx, event, updated, after); err != nil {
+		return nil, err
+	}
+
+	res := &SubmitResult{ETag: releaseETag(updated.UpdatedAt.Time, after), Warnings: conflicts}
+
+	if opts.IdempotencyKey != "" {
+		stored, err := s.storeIdempotent(ctx, qtx, opts.IdempotencyKey, reqHash, res)
+		if err != nil {
+			return nil, err
+		}
+		if !stored {
+			// A concurrent retry with the same key won the race; discard
+			// this attempt and return its result instead.
+			tx.Rollback(ctx)
+			replayed, err := s.replayIdempotent(ctx, opts.IdempotencyKey, reqHash)
+			if err != nil {
+				return nil, err
+			}
+			if replayed == nil {
+				return nil, fmt.Errorf("idempotency key %q vanished during submission", opts.IdempotencyKey)
+			}
+			return replayed, nil
 		}
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("commit: %w", err)
+		return nil, fmt.Errorf("commit: %w", err)
 	}
 
 	// Update in-memory tree (after commit)
@@ -112,26 +257,71 @@ func (s *svc) SubmitRelease(ctx context.Context, sub ReleaseSubmission) error {
 		}
 	}
 
+	s.scheduleEnrichment()
+
 	s.log.Info("release submitted", "version", r.Version, "submitted_by", r.SubmittedBy, "jira_count", len(sub.Changes))
-	return nil
+	return res, nil
 }
 
-func (s *svc) DeleteRelease(ctx context.Context, version string) error {
-	// Look up release to get its platform
-	rel, err := s.q.GetRelease(ctx, version)
+func (s *svc) DeleteRelease(ctx context.Context, version string, opts WriteOptions) error {
+	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("get release %s: %w", version, err)
+		return fmt.Errorf("begin tx: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	if err := s.q.DeleteRelease(ctx, version); err != nil {
+	qtx := s.q.WithTx(tx)
+
+	rel, exists, err := lockRelease(ctx, qtx, version, opts.IfMatch)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	// The platform is only known once the release has been looked up.
+	if err := requirePlatformRole(ctx, rel.Platform, RoleAdmin); err != nil {
+		return err
+	}
+	if err := s.checkLocked(ctx, rel, auditDelete, opts); err != nil {
+		return err
+	}
+
+	before, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return fmt.Errorf("get current jiras: %w", err)
+	}
+
+	// Soft delete: the jira links stay in place so the release can be
+	// restored until the purge job removes it.
+	if err := qtx.SoftDeleteRelease(ctx, version); err != nil {
 		return fmt.Errorf("delete release %s: %w", version, err)
 	}
 
+	_, removed := diffJiraIDs(before, nil)
+	if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+		ReleaseVersion: version,
+		Platform:       rel.Platform,
+		Action:         auditDelete,
+		Actor:          actorFrom(ctx, ""),
+		AddedJiras:     []string{},
+		RemovedJiras:   removed,
+	}); err != nil {
+		return fmt.Errorf("insert audit: %w", err)
+	}
+	if err := s.enqueueReleaseEvent(ctx, qtx, EventReleaseDeleted, rel, before); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
 	// Rebuild tree from DB
 	if err := s.tm.Rebuild(ctx, s.q, rel.Platform); err != nil {
 		s.log.Error("tree rebuild after delete failed", "platform", rel.Platform, "error", err)
 	}
 
-	s.log.Info("release deleted", "version", version, "platform", rel.Platform)
+	s.log.Info("release deleted", "version", version, "platform", rel.Platform, "actor", actorFrom(ctx, ""))
 	return nil
 }
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"jiraiya/internal/db"
+	"jiraiya/internal/releasetree"
+)
+
+// Audit actions recorded for partial release edits.
+const (
+	auditAddJira    = "add_jira"
+	auditRemoveJira = "remove_jira"
+	auditUpdate     = "update"
+)
+
+func (s *svc) AddReleaseJira(ctx context.Context, version string, j JiraInput, opts WriteOptions) (*SubmitResult, error) {
+	if j.ID == "" {
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: "jira id is required"}}}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	rel, exists, err := lockRelease(ctx, qtx, version, opts.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if err := requirePlatformRole(ctx, rel.Platform, RoleSubmitter); err != nil {
+		return nil, err
+	}
+	if err := s.checkLocked(ctx, rel, auditAddJira, opts); err != nil {
+		return nil, err
+	}
+
+	conflicts, revised, err := compareJiras(ctx, qtx, []JiraInput{j})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range conflicts {
+		s.log.Warn("jira metadata overwritten", "version", version, "jira_id", c.JiraID, "field", c.Field)
+	}
+	if err := qtx.UpsertJira(ctx, db.UpsertJiraParams{
+		ID:       j.ID,
+		Title:    j.Title,
+		Impact:   j.Impact,
+		Domain:   j.Domain,
+		Relnotes: j.Relnotes,
+	}); err != nil {
+		return nil, fmt.Errorf("upsert jira %s: %w", j.ID, err)
+	}
+	if err := insertJiraRevisions(ctx, qtx, version, revised); err != nil {
+		return nil, err
+	}
+
+	before, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get current jiras: %w", err)
+	}
+	if err := qtx.LinkJiraToRelease(ctx, db.LinkJiraToReleaseParams{ReleaseVersion: version, JiraID: j.ID}); err != nil {
+		return nil, fmt.Errorf("link jira %s: %w", j.ID, err)
+	}
+	after := append(before[:len(before):len(before)], j.ID)
+
+	payload, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit payload: %w", err)
+	}
+	res, err := s.finishJiraEdit(ctx, qtx, rel, auditAddJira, payload, before, after)
+	if err != nil {
+		return nil, err
+	}
+	res.Warnings = conflicts
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	if err := s.tm.AddChange(rel.Platform, version, releasetree.Chg{ID: j.ID}); err != nil {
+		s.log.Error("tree add change failed, rebuilding", "version", version, "error", err)
+		if rebuildErr := s.tm.Rebuild(ctx, s.q, rel.Platform); rebuildErr != nil {
+			s.log.Error("tree rebuild failed", "platform", rel.Platform, "error", rebuildErr)
+		}
+	}
+
+	s.scheduleEnrichment()
+
+	s.log.Info("jira added to release", "version", version, "jira_id", j.ID, "actor", actorFrom(ctx, ""))
+	return res, nil
+}
+
+func (s *svc) RemoveReleaseJira(ctx context.Context, version, jiraID string, opts WriteOptions) (*SubmitResult, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	rel, exists, err := lockRelease(ctx, qtx, version, opts.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if err := requirePlatformRole(ctx, rel.Platform, RoleSubmitter); err != nil {
+		return nil, err
+	}
+	if err := s.checkLocked(ctx, rel, auditRemoveJira, opts); err != nil {
+		return nil, err
+	}
+
+	before, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get current jiras: %w", err)
+	}
+	n, err := qtx.UnlinkJiraFromRelease(ctx, db.UnlinkJiraFromReleaseParams{ReleaseVersion: version, JiraID: jiraID})
+	if err != nil {
+		return nil, fmt.Errorf("unlink jira %s: %w", jiraID, err)
+	}
+	if n == 0 {
+		return nil, ErrNotFound
+	}
+	after := make([]string, 0, len(before))
+	for _, id := range before {
+		if id != jiraID {
+			after = append(after, id)
+		}
+	}
+
+	res, err := s.finishJiraEdit(ctx, qtx, rel, auditRemoveJira, nil, before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	if err := s.tm.RemoveChange(rel.Platform, version, jiraID); err != nil {
+		s.log.Error("tree remove change failed, rebuilding", "version", version, "error", err)
+		if rebuildErr := s.tm.Rebuild(ctx, s.q, rel.Platform); rebuildErr != nil {
+			s.log.Error("tree rebuild failed", "platform", rel.Platform, "error", rebuildErr)
+		}
+	}
+
+	s.log.Info("jira removed from release", "version", version, "jira_id", jiraID, "actor", actorFrom(ctx, ""))
+	return res, nil
+}
+
+// finishJiraEdit bumps the release's ETag and records the link change in the
+// audit log. It runs inside the edit's transaction.
+func (s *svc) finishJiraEdit(ctx context.Context, qtx *db.Queries, rel db.Release, action string, payload []byte, before, after []string) (*SubmitResult, error) {
+	if err := qtx.TouchRelease(ctx, rel.Version); err != nil {
+		return nil, fmt.Errorf("touch release %s: %w", rel.Version, err)
+	}
+
+	added, removed := diffJiraIDs(before, after)
+	if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+		ReleaseVersion: rel.Version,
+		Platform:       rel.Platform,
+		Action:         action,
+		Actor:          actorFrom(ctx, ""),
+		Payload:        payload,
+		AddedJiras:     added,
+		RemovedJiras:   removed,
+	}); err != nil {
+		return nil, fmt.Errorf("insert audit: %w", err)
+	}
+
+	updated, err := qtx.GetRelease(ctx, rel.Version)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", rel.Version, err)
+	}
+	if err := s.enqueueReleaseEvent(ctx, qtx, EventReleaseUpdated, updated, after); err != nil {
+		return nil, err
+	}
+	return &SubmitResult{ETag: releaseETag(updated.UpdatedAt.Time, after)}, nil
+}
+
+func (s *svc) UpdateRelease(ctx context.Context, version string, patch ReleasePatch, opts WriteOptions) (*ReleaseOutput, error) {
+	if patch.ReleaseDate == nil && patch.SubmittedBy == nil {
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: "at least one field is required"}}}
+	}
+	params := db.UpdateReleaseMetadataParams{
+		Version:     version,
+		SubmittedBy: optionalText(patch.SubmittedBy),
+	}
+	if patch.ReleaseDate != nil {
+		d, err := parseDate(*patch.ReleaseDate)
+		if err != nil {
+			return nil, &ValidationError{Details: []ValidationDetail{{Reason: "release_date must be a valid YYYY-MM-DD date"}}}
+		}
+		params.SetReleaseDate = true
+		params.ReleaseDate = d
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	rel, exists, err := lockRelease(ctx, qtx, version, opts.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	// Reattributing a release overrides what authentication recorded, so
+	// it takes an admin of the release's platform.
+	role := RoleSubmitter
+	if patch.SubmittedBy != nil {
+		role = RoleAdmin
+	}
+	if err := requirePlatformRole(ctx, rel.Platform, role); err != nil {
+		return nil, err
+	}
+	if err := s.checkLocked(ctx, rel, auditUpdate, opts); err != nil {
+		return nil, err
+	}
+
+	if err := qtx.UpdateReleaseMetadata(ctx, params); err != nil {
+		return nil, fmt.Errorf("update release %s: %w", version, err)
+	}
+
+	payload, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit payload: %w", err)
+	}
+	if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+		ReleaseVersion: version,
+		Platform:       rel.Platform,
+		Action:         auditUpdate,
+		Actor:          actorFrom(ctx, ""),
+		Payload:        payload,
+		AddedJiras:     []string{},
+		RemovedJiras:   []string{},
+	}); err != nil {
+		return nil, fmt.Errorf("insert audit: %w", err)
+	}
+
+	updated, err := qtx.GetRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", version, err)
+	}
+	ids, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras for %s: %w", version, err)
+	}
+	if err := s.enqueueReleaseEvent(ctx, qtx, EventReleaseUpdated, updated, ids); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	s.log.Info("release updated", "version", version, "actor", actorFrom(ctx, ""))
+	out := s.releaseOutput(updated)
+	out.ETag = releaseETag(updated.UpdatedAt.Time, ids)
+	return &out, nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"jiraiya/internal/db"
+)
+
+// jiraConflicts compares a submitted jira against its stored metadata and
+// returns one conflict per field the submission would overwrite. Empty
+// submitted fields are skipped, since the upsert keeps the stored value.
+func jiraConflicts(old db.Jira, j JiraInput) []MetadataConflict {
+	var out []MetadataConflict
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"title", old.Title, j.Title},
+		{"impact", old.Impact, j.Impact},
+		{"domain", old.Domain, j.Domain},
+		{"relnotes", old.Relnotes, j.Relnotes},
+	}
+	for _, f := range fields {
+		if f.new != "" && f.old != f.new {
+			out = append(out, MetadataConflict{JiraID: j.ID, Field: f.name, Old: f.old, New: f.new})
+		}
+	}
+	return out
+}
+
+// mergeJira returns j with its empty fields taken from old, which is what the
+// upsert stores.
+func mergeJira(old db.Jira, j JiraInput) JiraInput {
+	if j.Title == "" {
+		j.Title = old.Title
+	}
+	if j.Impact == "" {
+		j.Impact = old.Impact
+	}
+	if j.Domain == "" {
+		j.Domain = old.Domain
+	}
+	if j.Relnotes == "" {
+		j.Relnotes = old.Relnotes
+	}
+	return j
+}
+
+// compareJiras loads the stored metadata for the submitted jiras and returns
+// the conflicts for jiras that already existed, plus the jiras that need a new
+// revision because they are new or changed. It must run before the upsert.
+func compareJiras(ctx context.Context, qtx *db.Queries, changes []JiraInput) ([]MetadataConflict, []JiraInput, error) {
+	if len(changes) == 0 {
+		return nil, nil, nil
+	}
+
+	ids := make([]string, len(changes))
+	for i, j := range changes {
+		ids[i] = j.ID
+	}
+	existing, err := qtx.GetJirasByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get existing jiras: %w", err)
+	}
+	byID := make(map[string]db.Jira, len(existing))
+	for _, j := range existing {
+		byID[j.ID] = j
+	}
+
+	// The upsert applies changes in order, so the last occurrence of a
+	// duplicated id is the one that ends up stored.
+	last := make(map[string]int, len(changes))
+	for i, j := range changes {
+		last[j.ID] = i
+	}
+
+	var conflicts []MetadataConflict
+	var revised []JiraInput
+	for i, j := range changes {
+		if last[j.ID] != i {
+			continue
+		}
+		if old, exists := byID[j.ID]; exists {
+			c := jiraConflicts(old, j)
+			if len(c) == 0 {
+				continue
+			}
+			conflicts = append(conflicts, c...)
+			j = mergeJira(old, j)
+		}
+		revised = append(revised, j)
+	}
+	return conflicts, revised, nil
+}
+
+// insertJiraRevisions stores one revision per jira, attributed to version.
+// It must run after the jiras are upserted.
+func insertJiraRevisions(ctx context.Context, qtx *db.Queries, version string, jiras []JiraInput) error {
+	for _, j := range jiras {
+		if err := qtx.InsertJiraRevision(ctx, db.InsertJiraRevisionParams{
+			JiraID:         j.ID,
+			ReleaseVersion: version,
+			Title:          j.Title,
+			Impact:         j.Impact,
+			Domain:         j.Domain,
+			Relnotes:       j.Relnotes,
+		}); err != nil {
+			return fmt.Errorf("insert revision for %s: %w", j.ID, err)
+		}
+	}
+	return nil
+}
+
+// applyRevisionsAsOf overwrites the metadata in out with the latest revision
+// recorded at or before asOf. Jiras without a matching revision keep their
+// current metadata.
+func (s *svc) applyRevisionsAsOf(ctx context.Context, out []JiraOutput, ids []string, asOf pgtype.Timestamptz) error {
+	revs, err := s.q.GetJiraRevisionsAsOf(ctx, db.GetJiraRevisionsAsOfParams{Ids: ids, AsOf: asOf})
+	if err != nil {
+		return fmt.Errorf("get jira revisions: %w", err)
+	}
+	byID := make(map[string]db.GetJiraRevisionsAsOfRow, len(revs))
+	for _, r := range revs {
+		byID[r.JiraID] = r
+	}
+	for i := range out {
+		if r, ok := byID[out[i].ID]; ok {
+			out[i].Title = r.Title
+			out[i].Impact = r.Impact
+			out[i].Domain = r.Domain
+			out[i].Relnotes = r.Relnotes
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"jiraiya/internal/db"
+)
+
+// Audit actions recorded when a soft-deleted release is restored or purged.
+const (
+	auditRestore = "restore"
+	auditPurge   = "purge"
+)
+
+// defaultDeletedRetention is used when Config.DeletedRetention is zero.
+const defaultDeletedRetention = 30 * 24 * time.Hour
+
+func (s *svc) deletedRetention() time.Duration {
+	if s.cfg.DeletedRetention > 0 {
+		return s.cfg.DeletedRetention
+	}
+	return defaultDeletedRetention
+}
+
+func (s *svc) RestoreRelease(ctx context.Context, version string, opts WriteOptions) (*ReleaseOutput, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	// lockRelease hides soft-deleted rows, so lock the row directly.
+	rel, err := qtx.LockRelease(ctx, version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lock release %s: %w", version, err)
+	}
+	if !rel.DeletedAt.Valid {
+		return nil, ErrNotFound
+	}
+	if err := requirePlatformRole(ctx, rel.Platform, RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	ids, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras for %s: %w", version, err)
+	}
+	if opts.IfMatch != "" && !etagMatches(opts.IfMatch, releaseETag(rel.UpdatedAt.Time, ids)) {
+		return nil, ErrPreconditionFailed
+	}
+
+	// The release tree cannot hang a release off a missing parent.
+	if rel.FromVer != "" {
+		parent, err := qtx.GetRelease(ctx, rel.FromVer)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &ValidationError{Details: []ValidationDetail{{ID: rel.FromVer, Reason: fmt.Sprintf("parent release %q is deleted; restore it first", rel.FromVer)}}}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get release %s: %w", rel.FromVer, err)
+		}
+		if parent.Platform != rel.Platform {
+			return nil, &ValidationError{Details: []ValidationDetail{{ID: rel.FromVer, Reason: fmt.Sprintf("parent release %q is on platform %q", rel.FromVer, parent.Platform)}}}
+		}
+	}
+
+	if err := qtx.RestoreRelease(ctx, version); err != nil {
+		return nil, fmt.Errorf("restore release %s: %w", version, err)
+	}
+
+	added, _ := diffJiraIDs(nil, ids)
+	if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+		ReleaseVersion: version,
+		Platform:       rel.Platform,
+		Action:         auditRestore,
+		Actor:          actorFrom(ctx, ""),
+		AddedJiras:     added,
+		RemovedJiras:   []string{},
+	}); err != nil {
+		return nil, fmt.Errorf("insert audit: %w", err)
+	}
+
+	updated, err := qtx.GetRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", version, err)
+	}
+	if err := s.enqueueReleaseEvent(ctx, qtx, EventReleaseSubmitted, updated, ids); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	if err := s.tm.Rebuild(ctx, s.q, rel.Platform); err != nil {
+		s.log.Error("tree rebuild after restore failed", "platform", rel.Platform, "error", err)
+	}
+
+	s.log.Info("release restored", "version", version, "platform", rel.Platform, "actor", actorFrom(ctx, ""))
+	out := s.releaseOutput(updated)
+	out.ETag = releaseETag(updated.UpdatedAt.Time, ids)
+	return &out, nil
+}
+
+func (s *svc) PurgeDeletedReleases(ctx context.Context) (int, error) {
+	cutoff := time.Now().Add(-s.deletedRetention())
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	purged, err := qtx.PurgeDeletedReleases(ctx, pgtype.Timestamptz{Time: cutoff, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("purge deleted releases: %w", err)
+	}
+	for _, p := range purged {
+		if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+			ReleaseVersion: p.Version,
+			Platform:       p.Platform,
+			Action:         auditPurge,
+			Actor:          actorFrom(ctx, ""),
+			AddedJiras:     []string{},
+			RemovedJiras:   []string{},
+		}); err != nil {
+			return 0, fmt.Errorf("insert audit: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit: %w", err)
+	}
+
+	// Soft-deleted releases are already absent from the trees, so there is
+	// nothing to rebuild.
+	if len(purged) > 0 {
+		s.log.Info("deleted releases purged", "count", len(purged), "cutoff", cutoff)
+	}
+	return len(purged), nil
+}
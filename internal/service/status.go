@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"jiraiya/internal/db"
+)
+
+// Release lifecycle states.
+const (
+	StatusDraft     = "draft"
+	StatusRC        = "rc"
+	StatusGA        = "ga"
+	StatusWithdrawn = "withdrawn"
+)
+
+// auditTransition is the audit action recorded for a status change.
+const auditTransition = "transition"
+
+// transitions lists the states each state may move to. Withdrawn is terminal;
+// withdrawn releases stay in the tree so their descendants keep a parent.
+var transitions = map[string][]string{
+	StatusDraft:     {StatusRC, StatusGA, StatusWithdrawn},
+	StatusRC:        {StatusDraft, StatusGA, StatusWithdrawn},
+	StatusGA:        {StatusWithdrawn},
+	StatusWithdrawn: {},
+}
+
+// validStatus reports whether status is a known lifecycle state.
+func validStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// canTransition reports whether a release may move from one state to another.
+func canTransition(from, to string) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionError is returned when a status change is not allowed.
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot transition release from %s to %s", e.From, e.To)
+}
+
+func (s *svc) TransitionRelease(ctx context.Context, version, status string, opts WriteOptions) (*ReleaseOutput, error) {
+	if !validStatus(status) {
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: fmt.Sprintf("unknown status %q", status)}}}
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.q.WithTx(tx)
+
+	rel, exists, err := lockRelease(ctx, qtx, version, opts.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	if err := requirePlatformRole(ctx, rel.Platform, RoleSubmitter); err != nil {
+		return nil, err
+	}
+	if !canTransition(rel.Status, status) {
+		return nil, &TransitionError{From: rel.Status, To: status}
+	}
+
+	if err := qtx.UpdateReleaseStatus(ctx, db.UpdateReleaseStatusParams{Version: version, Status: status}); err != nil {
+		return nil, fmt.Errorf("update status: %w", err)
+	}
+
+	payload, err := json.Marshal(map[string]string{"from": rel.Status, "to": status})
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit payload: %w", err)
+	}
+	if err := qtx.InsertReleaseAudit(ctx, db.InsertReleaseAuditParams{
+		ReleaseVersion: version,
+		Platform:       rel.Platform,
+		Action:         auditTransition,
+		Actor:          actorFrom(ctx, ""),
+		Payload:        payload,
+		AddedJiras:     []string{},
+		RemovedJiras:   []string{},
+	}); err != nil {
+		return nil, fmt.Errorf("insert audit: %w", err)
+	}
+
+	updated, err := qtx.GetRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get release %s: %w", version, err)
+	}
+	ids, err := qtx.GetJiraIDsByRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("get jiras for %s: %w", version, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+
+	s.log.Info("release transitioned", "version", version, "from", rel.Status, "to", status, "actor", actorFrom(ctx, ""))
+	out := s.releaseOutput(updated)
+	out.ETag = releaseETag(updated.UpdatedAt.Time, ids)
+	return &out, nil
+}
+
+// nonGASkipper returns a skip function for CalcChgsAttributed that skips every
+// release on platform whose status is not GA.
+func (s *svc) nonGASkipper(ctx context.Context, platform string) (func(string) bool, error) {
+	rows, err := s.q.GetReleaseStatusesByPlatform(ctx, platform)
+	if err != nil {
+		return nil, fmt.Errorf("get release statuses: %w", err)
+	}
+	nonGA := make(map[string]bool)
+	for _, r := range rows {
+		if r.Status != StatusGA {
+			nonGA[r.Version] = true
+		}
+	}
+	return func(version string) bool { return nonGA[version] }, nil
+}
+
+// getRelease wraps GetRelease, mapping a missing row to ErrNotFound.
+func (s *svc) getRelease(ctx context.Context, version string) (db.Release, error) {
+	rel, err := s.q.GetRelease(ctx, version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return rel, ErrNotFound
+	}
+	if err != nil {
+		return rel, fmt.Errorf("get release %s: %w", version, err)
+	}
+	return rel, nil
+}
@@ -124,6 +124,70 @@ func (tm *TreeManager) Rebuild(ctx context.Context, q *db.Queries, platform stri
 	return tm.buildTree(ctx, q, platform)
 }
 
+// Cumulative delegates to the platform tree's Cumulative.
+func (tm *TreeManager) Cumulative(platform, version string) ([]releasetree.Chg, error) {
+	tm.mu.RLock()
+	tree, exists := tm.trees[platform]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no tree for platform %q", platform)
+	}
+	return tree.Cumulative(version)
+}
+
+// Node delegates to the platform tree's Node.
+func (tm *TreeManager) Node(platform, version string) (*releasetree.NodeInfo, error) {
+	tm.mu.RLock()
+	tree, exists := tm.trees[platform]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no tree for platform %q", platform)
+	}
+	n, err := tree.Node(version)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// Ancestors delegates to the platform tree's Ancestors.
+func (tm *TreeManager) Ancestors(platform, version string) ([]string, error) {
+	tm.mu.RLock()
+	tree, exists := tm.trees[platform]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no tree for platform %q", platform)
+	}
+	return tree.Ancestors(version)
+}
+
+// AddChange delegates to the platform tree's AddChange.
+func (tm *TreeManager) AddChange(platform, version string, chg releasetree.Chg) error {
+	tm.mu.RLock()
+	tree, exists := tm.trees[platform]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("no tree for platform %q", platform)
+	}
+	return tree.AddChange(version, chg)
+}
+
+// RemoveChange delegates to the platform tree's RemoveChange.
+func (tm *TreeManager) RemoveChange(platform, version, id string) error {
+	tm.mu.RLock()
+	tree, exists := tm.trees[platform]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("no tree for platform %q", platform)
+	}
+	return tree.RemoveChange(version, id)
+}
+
 // CalcChgs delegates to the platform tree's CalcChgs.
 func (tm *TreeManager) CalcChgs(platform, endVer, startVer string) ([]releasetree.Chg, error) {
 	tm.mu.RLock()
@@ -136,6 +200,18 @@ func (tm *TreeManager) CalcChgs(platform, endVer, startVer string) ([]releasetre
 	return tree.CalcChgs(endVer, startVer)
 }
 
+// CalcChgsAttributed delegates to the platform tree's CalcChgsAttributed.
+func (tm *TreeManager) CalcChgsAttributed(platform, endVer, startVer string, skip func(string) bool) ([]releasetree.AttributedChg, error) {
+	tm.mu.RLock()
+	tree, exists := tm.trees[platform]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no tree for platform %q", platform)
+	}
+	return tree.CalcChgsAttributed(endVer, startVer, skip)
+}
+
 // Dump returns the tree dump for a platform.
 func (tm *TreeManager) Dump(platform string) (*releasetree.TreeDump, error) {
 	tm.mu.RLock()
@@ -148,3 +224,19 @@ func (tm *TreeManager) Dump(platform string) (*releasetree.TreeDump, error) {
 	d := tree.Dump()
 	return &d, nil
 }
+
+// DumpSubtree returns the dump of a subtree of a platform's tree.
+func (tm *TreeManager) DumpSubtree(platform, root string, levels int) (*releasetree.TreeDump, error) {
+	tm.mu.RLock()
+	tree, exists := tm.trees[platform]
+	tm.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no tree for platform %q", platform)
+	}
+	d, err := tree.DumpSubtree(root, levels)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
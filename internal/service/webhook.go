@@ -0,0 +1,360 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"jiraiya/internal/db"
+)
+
+// Webhook event types.
+const (
+	EventReleaseSubmitted = "release.submitted"
+	EventReleaseUpdated   = "release.updated"
+	EventReleaseDeleted   = "release.deleted"
+)
+
+// Webhook delivery statuses. A failed attempt that will be retried leaves the
+// delivery pending; it only becomes dead once its attempts run out.
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryDead      = "dead"
+)
+
+func validEventType(t string) bool {
+	switch t {
+	case EventReleaseSubmitted, EventReleaseUpdated, EventReleaseDeleted:
+		return true
+	}
+	return false
+}
+
+func validDeliveryStatus(st string) bool {
+	switch st {
+	case DeliveryPending, DeliverySucceeded, DeliveryDead:
+		return true
+	}
+	return false
+}
+
+func (s *svc) CreateWebhook(ctx context.Context, in WebhookInput) (*Webhook, error) {
+	var details []ValidationDetail
+	if in.Platform == "" {
+		details = append(details, ValidationDetail{Reason: "platform is required"})
+	}
+	if u, err := url.Parse(in.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		details = append(details, ValidationDetail{Reason: "url must be an absolute http or https URL"})
+	}
+	if in.Secret == "" {
+		details = append(details, ValidationDetail{Reason: "secret is required"})
+	}
+	for i, e := range in.Events {
+		if !validEventType(e) {
+			details = append(details, ValidationDetail{Index: i, Reason: fmt.Sprintf("unknown event type %q", e)})
+		}
+	}
+	if len(details) > 0 {
+		return nil, &ValidationError{Details: details}
+	}
+	if err := requirePlatformRole(ctx, in.Platform, RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	events := in.Events
+	if events == nil {
+		events = []string{}
+	}
+	sub, err := s.q.InsertWebhookSubscription(ctx, db.InsertWebhookSubscriptionParams{
+		Platform: in.Platform,
+		Url:      in.URL,
+		Secret:   in.Secret,
+		Events:   events,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert webhook subscription: %w", err)
+	}
+
+	s.log.Info("webhook subscribed", "id", sub.ID, "platform", sub.Platform, "url", sub.Url, "actor", actorFrom(ctx, ""))
+	out := webhookOutput(sub)
+	return &out, nil
+}
+
+func (s *svc) ListWebhooks(ctx context.Context, platform string) ([]Webhook, error) {
+	if platform != "" {
+		if err := requirePlatformRole(ctx, platform, RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+	platforms, all := grantedPlatforms(ctx, RoleAdmin)
+	subs, err := s.q.ListWebhookSubscriptions(ctx, db.ListWebhookSubscriptionsParams{
+		Platform:     platform,
+		AllPlatforms: all,
+		Platforms:    platforms,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+	out := make([]Webhook, len(subs))
+	for i, sub := range subs {
+		out[i] = webhookOutput(sub)
+	}
+	return out, nil
+}
+
+func (s *svc) GetWebhook(ctx context.Context, id int64) (*Webhook, error) {
+	sub, err := s.q.GetWebhookSubscription(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get webhook subscription %d: %w", id, err)
+	}
+	if err := requirePlatformRole(ctx, sub.Platform, RoleAdmin); err != nil {
+		return nil, err
+	}
+	out := webhookOutput(sub)
+	return &out, nil
+}
+
+func (s *svc) DeleteWebhook(ctx context.Context, id int64) error {
+	if _, err := s.GetWebhook(ctx, id); err != nil {
+		return err
+	}
+	n, err := s.q.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return fmt.Errorf("delete webhook subscription %d: %w", id, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	s.log.Info("webhook unsubscribed", "id", id, "actor", actorFrom(ctx, ""))
+	return nil
+}
+
+func (s *svc) ListWebhookDeliveries(ctx context.Context, dq DeliveryQuery) ([]WebhookDelivery, error) {
+	if dq.Status != "" && !validDeliveryStatus(dq.Status) {
+		return nil, &ValidationError{Details: []ValidationDetail{{Reason: fmt.Sprintf("unknown delivery status %q", dq.Status)}}}
+	}
+	limit, err := pageBounds(dq.Limit, dq.Offset)
+	if err != nil {
+		return nil, err
+	}
+	if dq.SubscriptionID != 0 {
+		if _, err := s.GetWebhook(ctx, dq.SubscriptionID); err != nil {
+			return nil, err
+		}
+	}
+	if dq.Platform != "" {
+		if err := requirePlatformRole(ctx, dq.Platform, RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+
+	platforms, all := grantedPlatforms(ctx, RoleAdmin)
+	rows, err := s.q.ListWebhookDeliveries(ctx, db.ListWebhookDeliveriesParams{
+		SubscriptionID: dq.SubscriptionID,
+		Platform:       dq.Platform,
+		Status:         dq.Status,
+		AllPlatforms:   all,
+		Platforms:      platforms,
+		PageLimit:      int32(limit),
+		PageOffset:     int32(dq.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	out := make([]WebhookDelivery, len(rows))
+	for i, r := range rows {
+		out[i] = deliveryOutput(r)
+	}
+	return out, nil
+}
+
+func (s *svc) RetryWebhookDelivery(ctx context.Context, id int64) error {
+	platform, err := s.q.GetWebhookDeliveryPlatform(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("get webhook delivery %d: %w", id, err)
+	}
+	if err := requirePlatformRole(ctx, platform, RoleAdmin); err != nil {
+		return err
+	}
+	n, err := s.q.RetryWebhookDelivery(ctx, id)
+	if err != nil {
+		return fmt.Errorf("retry webhook delivery %d: %w", id, err)
+	}
+	// Only dead deliveries can be retried; anything else is still in flight
+	// or already delivered.
+	if n == 0 {
+		return ErrNotFound
+	}
+	s.log.Info("webhook delivery requeued", "id", id, "actor", actorFrom(ctx, ""))
+	return nil
+}
+
+func (s *svc) ListReleaseEvents(ctx context.Context, eq EventQuery) ([]ReleaseEvent, error) {
+	limit, err := pageBounds(eq.Limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	if eq.Platform != "" {
+		if err := requirePlatformRole(ctx, eq.Platform, RoleReader); err != nil {
+			return nil, err
+		}
+	}
+	tx, qtx, err := s.beginEventRead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Without a platform, stream only the platforms the caller can read.
+	platforms, all := grantedPlatforms(ctx, RoleReader)
+	rows, err := qtx.ListWebhookEvents(ctx, db.ListWebhookEventsParams{
+		AfterID:      eq.AfterID,
+		Platform:     eq.Platform,
+		AllPlatforms: all,
+		Platforms:    platforms,
+		PageLimit:    int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list webhook events: %w", err)
+	}
+	out := make([]ReleaseEvent, len(rows))
+	for i, r := range rows {
+		out[i].ID = r.ID
+		if err := json.Unmarshal(r.Payload, &out[i].WebhookEvent); err != nil {
+			return nil, fmt.Errorf("decode webhook event %d: %w", r.ID, err)
+		}
+	}
+	return out, nil
+}
+
+func (s *svc) LatestReleaseEventID(ctx context.Context) (int64, error) {
+	tx, qtx, err := s.beginEventRead(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	id, err := qtx.GetLatestWebhookEventID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get latest webhook event id: %w", err)
+	}
+	return id, nil
+}
+
+// beginEventRead starts a transaction for reading the event log by id. Event
+// ids are drawn before the inserting transaction commits, so without waiting a
+// reader could pass an id that becomes visible later.
+func (s *svc) beginEventRead(ctx context.Context) (pgx.Tx, *db.Queries, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("begin tx: %w", err)
+	}
+	qtx := s.q.WithTx(tx)
+	if err := qtx.WaitWebhookEvents(ctx); err != nil {
+		tx.Rollback(ctx)
+		return nil, nil, fmt.Errorf("wait for webhook events: %w", err)
+	}
+	return tx, qtx, nil
+}
+
+// enqueueReleaseEvent writes an event for rel to the webhook outbox and queues
+// a delivery for every matching subscription. It runs inside the caller's
+// transaction, so the event is committed if and only if the change is. ids are
+// the release's own jiras after the change, or before it for a deletion; as
+// each release links only what it adds over its parent, they are its diff
+// against the parent.
+func (s *svc) enqueueReleaseEvent(ctx context.Context, qtx *db.Queries, typ string, rel db.Release, ids []string) error {
+	ids, _ = diffJiraIDs(nil, ids)
+	jiras, err := qtx.GetJirasByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("get jiras by ids: %w", err)
+	}
+	byID := make(map[string]db.Jira, len(jiras))
+	for _, j := range jiras {
+		byID[j.ID] = j
+	}
+	diff := make([]JiraOutput, 0, len(ids))
+	for _, id := range ids {
+		if j, ok := byID[id]; ok {
+			diff = append(diff, jiraOutput(j))
+		}
+	}
+
+	payload, err := json.Marshal(WebhookEvent{
+		Type:       typ,
+		OccurredAt: time.Now().UTC(),
+		Actor:      actorFrom(ctx, rel.SubmittedBy),
+		Release:    s.releaseOutput(rel),
+		Jiras:      diff,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook event: %w", err)
+	}
+
+	if err := qtx.LockWebhookEvents(ctx); err != nil {
+		return fmt.Errorf("lock webhook events: %w", err)
+	}
+	eventID, err := qtx.InsertWebhookEvent(ctx, db.InsertWebhookEventParams{
+		Platform:       rel.Platform,
+		EventType:      typ,
+		ReleaseVersion: rel.Version,
+		Payload:        payload,
+	})
+	if err != nil {
+		return fmt.Errorf("insert webhook event: %w", err)
+	}
+	if _, err := qtx.QueueWebhookDeliveries(ctx, eventID); err != nil {
+		return fmt.Errorf("queue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func webhookOutput(sub db.WebhookSubscription) Webhook {
+	events := sub.Events
+	if events == nil {
+		events = []string{}
+	}
+	return Webhook{
+		ID:        sub.ID,
+		Platform:  sub.Platform,
+		URL:       sub.Url,
+		Events:    events,
+		CreatedAt: sub.CreatedAt.Time,
+	}
+}
+
+func deliveryOutput(r db.ListWebhookDeliveriesRow) WebhookDelivery {
+	d := WebhookDelivery{
+		ID:             r.ID,
+		EventID:        r.EventID,
+		SubscriptionID: r.SubscriptionID,
+		Platform:       r.Platform,
+		EventType:      r.EventType,
+		Version:        r.ReleaseVersion,
+		Status:         r.Status,
+		Attempts:       int(r.Attempts),
+		LastStatusCode: int(r.LastStatusCode),
+		LastError:      r.LastError,
+		CreatedAt:      r.CreatedAt.Time,
+	}
+	if r.Status == DeliveryPending {
+		t := r.NextAttemptAt.Time
+		d.NextAttemptAt = &t
+	}
+	if r.DeliveredAt.Valid {
+		t := r.DeliveredAt.Time
+		d.DeliveredAt = &t
+	}
+	return d
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"jiraiya/internal/db"
+)
+
+// Headers sent with every webhook delivery. The signature is
+// "sha256=" followed by the hex HMAC-SHA256 of the body keyed by the
+// subscription secret.
+const (
+	webhookEventHeader     = "X-Jiraiya-Event"
+	webhookDeliveryHeader  = "X-Jiraiya-Delivery"
+	webhookSignatureHeader = "X-Jiraiya-Signature"
+)
+
+// Defaults used when the corresponding Config field is zero.
+const (
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookBackoff     = 30 * time.Second
+	defaultWebhookTimeout     = 10 * time.Second
+)
+
+// maxWebhookBackoff caps the delay between retries.
+const maxWebhookBackoff = time.Hour
+
+// webhookBatchSize is how many due deliveries DeliverWebhooks claims per call.
+const webhookBatchSize = 50
+
+func (s *svc) webhookMaxAttempts() int {
+	if s.cfg.WebhookMaxAttempts > 0 {
+		return s.cfg.WebhookMaxAttempts
+	}
+	return defaultWebhookMaxAttempts
+}
+
+func (s *svc) webhookBackoff() time.Duration {
+	if s.cfg.WebhookBackoff > 0 {
+		return s.cfg.WebhookBackoff
+	}
+	return defaultWebhookBackoff
+}
+
+func (s *svc) webhookTimeout() time.Duration {
+	if s.cfg.WebhookTimeout > 0 {
+		return s.cfg.WebhookTimeout
+	}
+	return defaultWebhookTimeout
+}
+
+// retryDelay is the backoff before the retry that follows the given failed
+// attempt: the base delay doubled for every earlier attempt, capped at
+// maxWebhookBackoff.
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	d := base
+	for i := 1; i < attempt && d < maxWebhookBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxWebhookBackoff)
+}
+
+// DeliverWebhooks sends one batch of due deliveries and records the outcome of
+// each. It returns how many deliveries were attempted.
+func (s *svc) DeliverWebhooks(ctx context.Context) (int, error) {
+	// The batch is sent one delivery after another, so hold the lease for as
+	// long as the whole batch can take plus a request's worth of slack.
+	// Another worker then never picks up a delivery that is still waiting
+	// its turn or in flight.
+	lease := time.Now().Add(time.Duration(webhookBatchSize+1) * s.webhookTimeout())
+	due, err := s.q.ClaimWebhookDeliveries(ctx, db.ClaimWebhookDeliveriesParams{
+		BatchSize:  webhookBatchSize,
+		LeaseUntil: pgtype.Timestamptz{Time: lease, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+
+	for _, d := range due {
+		code, sendErr := s.sendWebhook(ctx, d)
+		now := time.Now()
+		params := db.RecordWebhookAttemptParams{
+			ID:             d.ID,
+			Status:         DeliverySucceeded,
+			NextAttemptAt:  pgtype.Timestamptz{Time: now, Valid: true},
+			LastStatusCode: int32(code),
+		}
+		switch {
+		case sendErr == nil:
+			params.DeliveredAt = pgtype.Timestamptz{Time: now, Valid: true}
+		case int(d.Attempts) >= s.webhookMaxAttempts():
+			params.Status = DeliveryDead
+			params.LastError = sendErr.Error()
+			s.log.Warn("webhook delivery dead-lettered", "id", d.ID, "url", d.Url, "attempts", d.Attempts, "error", sendErr)
+		default:
+			params.Status = DeliveryPending
+			params.LastError = sendErr.Error()
+			params.NextAttemptAt.Time = now.Add(retryDelay(s.webhookBackoff(), int(d.Attempts)))
+			s.log.Info("webhook delivery failed, retrying", "id", d.ID, "url", d.Url, "attempt", d.Attempts, "error", sendErr)
+		}
+		if err := s.q.RecordWebhookAttempt(ctx, params); err != nil {
+			return 0, fmt.Errorf("record webhook attempt %d: %w", d.ID, err)
+		}
+	}
+	return len(due), nil
+}
+
+// sendWebhook posts a claimed delivery to its subscriber. It returns the
+// response status, or zero when no response arrived, and an error for
+// anything but a 2xx.
+func (s *svc) sendWebhook(ctx context.Context, d db.ClaimWebhookDeliveriesRow) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.webhookTimeout())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "jiraiya-webhooks")
+	req.Header.Set(webhookEventHeader, d.EventType)
+	req.Header.Set(webhookDeliveryHeader, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(webhookSignatureHeader, signWebhook(d.Secret, d.Payload))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
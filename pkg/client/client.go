@@ -0,0 +1,313 @@
+// Package client is a typed Go client for the jiraiya HTTP API. It has a
+// method for every route the server registers, shares its request and
+// response types with the server, and retries idempotent requests that fail
+// transiently.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"jiraiya/internal/service"
+)
+
+// Request and response types shared with the server.
+type (
+	JiraInput         = service.JiraInput
+	ReleaseInfo       = service.ReleaseInfo
+	ReleaseSubmission = service.ReleaseSubmission
+	ReleasePatch      = service.ReleasePatch
+	WriteOptions      = service.WriteOptions
+	SubmitResult      = service.SubmitResult
+	MetadataConflict  = service.MetadataConflict
+	ReleaseOutput     = service.ReleaseOutput
+	ReleaseList       = service.ReleaseList
+	AuditEntry        = service.AuditEntry
+	DiffOptions       = service.DiffOptions
+	Filters           = service.Filters
+	ListQuery         = service.ListQuery
+	VersionQuery      = service.VersionQuery
+	VersionList       = service.VersionList
+	VersionInfo       = service.VersionInfo
+	VersionRef        = service.VersionRef
+	Comparison        = service.Comparison
+	TreeQuery         = service.TreeQuery
+	TreeInfo          = service.TreeInfo
+	JiraOutput        = service.JiraOutput
+	JiraDetail        = service.JiraDetail
+	JiraPatch         = service.JiraPatch
+	JiraSearch        = service.JiraSearch
+	JiraPage          = service.JiraPage
+	ContentsQuery     = service.ContentsQuery
+	FeedQuery         = service.FeedQuery
+	WebhookInput      = service.WebhookInput
+	Webhook           = service.Webhook
+	WebhookEvent      = service.WebhookEvent
+	WebhookDelivery   = service.WebhookDelivery
+	DeliveryQuery     = service.DeliveryQuery
+	TokenInput        = service.TokenInput
+	Grant             = service.Grant
+	APIToken          = service.APIToken
+	CreatedToken      = service.CreatedToken
+)
+
+// Retry defaults used unless WithRetry overrides them.
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = 200 * time.Millisecond
+	maxBackoff         = 10 * time.Second
+)
+
+// Client calls the jiraiya API. It is safe for concurrent use.
+type Client struct {
+	baseURL     string
+	token       string
+	http        *http.Client
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithToken sends token as a bearer token on every request. The server
+// attributes writes to the token's name.
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithHTTPClient replaces http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// WithRetry sets how many times an idempotent request is tried and the delay
+// before the first retry, which doubles on each further retry. One attempt
+// disables retries.
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = max(maxAttempts, 1)
+		c.backoff = backoff
+	}
+}
+
+// New creates a Client for the server at baseURL, e.g. http://localhost:8080.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		http:        http.DefaultClient,
+		maxAttempts: defaultMaxAttempts,
+		backoff:     defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// request describes one API call.
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   any
+}
+
+// do sends req and decodes the JSON response into out, if set. The response
+// is returned for its headers; its body is already closed.
+func (c *Client) do(ctx context.Context, req request, out any) (*http.Response, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return resp, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("decode %s %s response: %w", req.method, req.path, err)
+	}
+	return resp, nil
+}
+
+// stream sends req and returns the response body unread. The caller must
+// close it.
+func (c *Client) stream(ctx context.Context, req request) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// send performs req, retrying idempotent methods on transport errors and
+// transient statuses, and turns non-2xx responses into an *APIError. On
+// success the caller owns the response body.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return nil, fmt.Errorf("marshal request: %w", err)
+		}
+	}
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	attempts := 1
+	if idempotent(req.method) {
+		attempts = c.maxAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := c.attempt(ctx, req, u, body)
+		if attempt >= attempts || !retryable(ctx, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return resp, nil
+			}
+			defer resp.Body.Close()
+			return nil, decodeError(resp)
+		}
+
+		delay := c.retryDelay(attempt, resp)
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, req request, u string, body []byte) (*http.Response, error) {
+	var rd io.Reader
+	if body != nil {
+		rd = bytes.NewReader(body)
+	}
+	hr, err := http.NewRequestWithContext(ctx, req.method, u, rd)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	for k, vs := range req.header {
+		hr.Header[k] = vs
+	}
+	hr.Header.Set("Accept", "application/json")
+	if body != nil {
+		hr.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		hr.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(hr)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", req.method, req.path, err)
+	}
+	return resp, nil
+}
+
+// idempotent reports whether repeating a request with method has the same
+// effect as sending it once, which makes it safe to retry.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// retryable reports whether a failed attempt is worth repeating: the network
+// failed, or the server is overloaded or briefly unavailable.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryDelay is the wait before the next attempt: the server's Retry-After if
+// it sent one, otherwise an exponential backoff with jitter.
+func (c *Client) retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs >= 0 {
+			return min(time.Duration(secs)*time.Second, maxBackoff)
+		}
+	}
+	d := min(c.backoff<<(attempt-1), maxBackoff)
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// writeHeader turns write options into request headers.
+func writeHeader(opts WriteOptions) http.Header {
+	h := http.Header{}
+	if opts.IfMatch != "" {
+		h.Set("If-Match", opts.IfMatch)
+	}
+	if opts.IdempotencyKey != "" {
+		h.Set("Idempotency-Key", opts.IdempotencyKey)
+	}
+	if opts.Override != "" {
+		h.Set("X-Admin-Override", opts.Override)
+	}
+	return h
+}
+
+func listParams(q url.Values, lq ListQuery) {
+	if lq.Sort != "" {
+		q.Set("sort", lq.Sort)
+	}
+	if lq.Order != "" {
+		q.Set("order", lq.Order)
+	}
+	if lq.Limit > 0 {
+		q.Set("limit", strconv.Itoa(lq.Limit))
+	}
+	if lq.Cursor != "" {
+		q.Set("cursor", lq.Cursor)
+	}
+}
+
+func pageParams(q url.Values, limit, offset int) {
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+}
+
+// pageHeaders reads the total count and next cursor of a list response.
+func pageHeaders(resp *http.Response) (int64, string) {
+	total, _ := strconv.ParseInt(resp.Header.Get("X-Total-Count"), 10, 64)
+	return total, resp.Header.Get("X-Next-Cursor")
+}
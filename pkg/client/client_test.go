@@ -0,0 +1,423 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jiraiya/internal/handler"
+	"jiraiya/internal/releasetree"
+	"jiraiya/internal/service"
+)
+
+// fakeService records the calls the real handler makes and answers with
+// canned values. err, when set, is returned by every method.
+type fakeService struct {
+	service.Service
+
+	mu    sync.Mutex
+	calls []string
+	actor string
+	opts  service.WriteOptions
+	sub   service.ReleaseSubmission
+	lq    service.ListQuery
+	vq    service.VersionQuery
+	err   error
+}
+
+func (f *fakeService) record(ctx context.Context, name string, opts service.WriteOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, name)
+	f.opts = opts
+	if id := service.IdentityFrom(ctx); id != nil {
+		f.actor = id.Name
+	}
+	return f.err
+}
+
+func (f *fakeService) lastCall() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.calls) == 0 {
+		return ""
+	}
+	return f.calls[len(f.calls)-1]
+}
+
+var testRelease = service.ReleaseOutput{Version: "1.0", Platform: "android", Status: "GA", ETag: `"r1"`}
+
+func (f *fakeService) SubmitRelease(ctx context.Context, sub service.ReleaseSubmission, opts service.WriteOptions) (*service.SubmitResult, error) {
+	f.sub = sub
+	if err := f.record(ctx, "SubmitRelease", opts); err != nil {
+		return nil, err
+	}
+	return &service.SubmitResult{
+		ETag:     `"r1"`,
+		Replayed: opts.IdempotencyKey != "",
+		Warnings: []service.MetadataConflict{{JiraID: "A-1", Field: "title", Old: "old", New: "new"}},
+	}, nil
+}
+
+func (f *fakeService) DeleteRelease(ctx context.Context, version string, opts service.WriteOptions) error {
+	return f.record(ctx, "DeleteRelease", opts)
+}
+
+func (f *fakeService) GetReleases(ctx context.Context, version, platform string) ([]service.ReleaseOutput, error) {
+	return []service.ReleaseOutput{testRelease}, f.record(ctx, "GetReleases", service.WriteOptions{})
+}
+
+func (f *fakeService) GetFilters(ctx context.Context, platform string) (*service.Filters, error) {
+	return &service.Filters{Domains: []string{"Player"}, Impacts: []string{"High"}}, f.record(ctx, "GetFilters", service.WriteOptions{})
+}
+
+func (f *fakeService) ListReleases(ctx context.Context, platform string, lq service.ListQuery) (*service.ReleaseList, error) {
+	f.lq = lq
+	list := &service.ReleaseList{Releases: []service.ReleaseOutput{testRelease}, Total: 7}
+	if lq.Cursor != "next" {
+		list.NextCursor = "next"
+	}
+	return list, f.record(ctx, "ListReleases", service.WriteOptions{})
+}
+
+func (f *fakeService) GetVersions(ctx context.Context, platform string, vq service.VersionQuery) (*service.VersionList, error) {
+	f.vq = vq
+	return &service.VersionList{Versions: []service.VersionInfo{{Version: "1.0"}}, Total: 1}, f.record(ctx, "GetVersions", service.WriteOptions{})
+}
+
+func (f *fakeService) GetJirasBetweenVersions(ctx context.Context, fromVer, toVer string, opts service.DiffOptions) ([]service.JiraOutput, error) {
+	return []service.JiraOutput{{ID: "A-1", Release: toVer}}, f.record(ctx, "GetJirasBetweenVersions", service.WriteOptions{})
+}
+
+func (f *fakeService) GetTreeInfo(ctx context.Context, platform string, tq service.TreeQuery) (*service.TreeInfo, error) {
+	return &service.TreeInfo{Platform: platform, NodeCount: 1, Root: "1.0", Nodes: []releasetree.NodeInfo{{Version: "1.0"}}}, f.record(ctx, "GetTreeInfo", service.WriteOptions{})
+}
+
+func (f *fakeService) GetReleaseHistory(ctx context.Context, version string) ([]service.AuditEntry, error) {
+	return []service.AuditEntry{{ID: 1, Version: version, Action: "submit"}}, f.record(ctx, "GetReleaseHistory", service.WriteOptions{})
+}
+
+func (f *fakeService) TransitionRelease(ctx context.Context, version, status string, opts service.WriteOptions) (*service.ReleaseOutput, error) {
+	rel := testRelease
+	rel.Status = status
+	return &rel, f.record(ctx, "TransitionRelease", opts)
+}
+
+func (f *fakeService) SetReleaseLock(ctx context.Context, version string, locked bool, opts service.WriteOptions) (*service.ReleaseOutput, error) {
+	rel := testRelease
+	rel.Locked = locked
+	return &rel, f.record(ctx, "SetReleaseLock", opts)
+}
+
+func (f *fakeService) RestoreRelease(ctx context.Context, version string, opts service.WriteOptions) (*service.ReleaseOutput, error) {
+	return &testRelease, f.record(ctx, "RestoreRelease", opts)
+}
+
+func (f *fakeService) GetJira(ctx context.Context, id string) (*service.JiraDetail, error) {
+	if err := f.record(ctx, "GetJira", service.WriteOptions{}); err != nil {
+		return nil, err
+	}
+	return &service.JiraDetail{JiraOutput: service.JiraOutput{ID: id}, Releases: []string{"1.0"}}, nil
+}
+
+func (f *fakeService) SearchJiras(ctx context.Context, js service.JiraSearch) (*service.JiraPage, error) {
+	return &service.JiraPage{Jiras: []service.JiraOutput{{ID: "A-1"}}, Total: 1, Limit: js.Limit}, f.record(ctx, "SearchJiras", service.WriteOptions{})
+}
+
+func (f *fakeService) UpdateJira(ctx context.Context, id string, patch service.JiraPatch) (*service.JiraOutput, error) {
+	return &service.JiraOutput{ID: id, Title: *patch.Title}, f.record(ctx, "UpdateJira", service.WriteOptions{})
+}
+
+func (f *fakeService) AddReleaseJira(ctx context.Context, version string, j service.JiraInput, opts service.WriteOptions) (*service.SubmitResult, error) {
+	return &service.SubmitResult{ETag: `"r2"`}, f.record(ctx, "AddReleaseJira", opts)
+}
+
+func (f *fakeService) RemoveReleaseJira(ctx context.Context, version, jiraID string, opts service.WriteOptions) (*service.SubmitResult, error) {
+	return &service.SubmitResult{ETag: `"r3"`}, f.record(ctx, "RemoveReleaseJira:"+jiraID, opts)
+}
+
+func (f *fakeService) UpdateRelease(ctx context.Context, version string, patch service.ReleasePatch, opts service.WriteOptions) (*service.ReleaseOutput, error) {
+	return &testRelease, f.record(ctx, "UpdateRelease", opts)
+}
+
+func (f *fakeService) CompareVersions(ctx context.Context, left, right service.VersionRef) (*service.Comparison, error) {
+	return &service.Comparison{Left: left, Right: right}, f.record(ctx, "CompareVersions", service.WriteOptions{})
+}
+
+func (f *fakeService) GetReleaseContents(ctx context.Context, version string, cq service.ContentsQuery) (*service.JiraPage, error) {
+	return &service.JiraPage{Total: 0, Limit: cq.Limit}, f.record(ctx, "GetReleaseContents", service.WriteOptions{})
+}
+
+func (f *fakeService) GetFeed(ctx context.Context, platform string, fq service.FeedQuery) (*service.Feed, error) {
+	return &service.Feed{Platform: platform, Updated: time.Now()}, f.record(ctx, "GetFeed", service.WriteOptions{})
+}
+
+func (f *fakeService) CreateWebhook(ctx context.Context, in service.WebhookInput) (*service.Webhook, error) {
+	return &service.Webhook{ID: 1, Platform: in.Platform, URL: in.URL}, f.record(ctx, "CreateWebhook", service.WriteOptions{})
+}
+
+func (f *fakeService) ListWebhooks(ctx context.Context, platform string) ([]service.Webhook, error) {
+	return []service.Webhook{{ID: 1}}, f.record(ctx, "ListWebhooks", service.WriteOptions{})
+}
+
+func (f *fakeService) GetWebhook(ctx context.Context, id int64) (*service.Webhook, error) {
+	return &service.Webhook{ID: id}, f.record(ctx, "GetWebhook", service.WriteOptions{})
+}
+
+func (f *fakeService) DeleteWebhook(ctx context.Context, id int64) error {
+	return f.record(ctx, "DeleteWebhook", service.WriteOptions{})
+}
+
+func (f *fakeService) ListWebhookDeliveries(ctx context.Context, dq service.DeliveryQuery) ([]service.WebhookDelivery, error) {
+	name := "ListWebhookDeliveries"
+	if dq.Status == service.DeliveryDead && dq.SubscriptionID == 0 {
+		name = "DeadLetters"
+	}
+	return []service.WebhookDelivery{{ID: 1, Status: dq.Status}}, f.record(ctx, name, service.WriteOptions{})
+}
+
+func (f *fakeService) RetryWebhookDelivery(ctx context.Context, id int64) error {
+	return f.record(ctx, "RetryWebhookDelivery", service.WriteOptions{})
+}
+
+func (f *fakeService) CreateToken(ctx context.Context, in service.TokenInput) (*service.CreatedToken, error) {
+	return &service.CreatedToken{APIToken: service.APIToken{ID: 1, Name: in.Name, Role: in.Role}, Token: "jy_x"}, f.record(ctx, "CreateToken", service.WriteOptions{})
+}
+
+func (f *fakeService) ListTokens(ctx context.Context) ([]service.APIToken, error) {
+	return []service.APIToken{{ID: 1}}, f.record(ctx, "ListTokens", service.WriteOptions{})
+}
+
+func (f *fakeService) RevokeToken(ctx context.Context, id int64) error {
+	return f.record(ctx, "RevokeToken", service.WriteOptions{})
+}
+
+// Authenticate treats every token as an admin named after the token.
+func (f *fakeService) Authenticate(ctx context.Context, token string) (*service.Identity, error) {
+	return &service.Identity{Name: token, Role: service.RoleAdmin}, nil
+}
+
+// newTestServer serves the real routes over svc.
+func newTestServer(t *testing.T, svc *fakeService) *httptest.Server {
+	t.Helper()
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	srv := httptest.NewServer(handler.New(svc, log).Routes())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestEveryRoute(t *testing.T) {
+	svc := &fakeService{}
+	c := New(newTestServer(t, svc).URL, WithToken("alice"))
+	ctx := context.Background()
+	title := "new"
+
+	readAll := func(rc io.ReadCloser, err error) error {
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+		_, err = io.ReadAll(rc)
+		return err
+	}
+
+	tests := []struct {
+		want string
+		call func() error
+	}{
+		{"GetReleases", func() error { _, err := c.Release(ctx, "1.0"); return err }},
+		{"ListReleases", func() error { _, err := c.ListReleases(ctx, "android", ListQuery{}); return err }},
+		{"ListReleases", func() error { return readAll(c.ExportReleases(ctx, "android", FormatCSV)) }},
+		{"SubmitRelease", func() error { _, err := c.SubmitRelease(ctx, ReleaseSubmission{}, WriteOptions{}); return err }},
+		{"UpdateRelease", func() error {
+			_, err := c.UpdateRelease(ctx, "1.0", ReleasePatch{SubmittedBy: &title}, WriteOptions{})
+			return err
+		}},
+		{"DeleteRelease", func() error { return c.DeleteRelease(ctx, "1.0", WriteOptions{}) }},
+		{"GetReleaseHistory", func() error { _, err := c.ReleaseHistory(ctx, "1.0"); return err }},
+		{"GetReleaseContents", func() error { _, err := c.ReleaseContents(ctx, "1.0", ContentsQuery{Limit: 5}); return err }},
+		{"TransitionRelease", func() error { _, err := c.TransitionRelease(ctx, "1.0", "beta", WriteOptions{}); return err }},
+		{"SetReleaseLock", func() error { _, err := c.SetReleaseLock(ctx, "1.0", true, WriteOptions{}); return err }},
+		{"SetReleaseLock", func() error { _, err := c.SetReleaseLock(ctx, "1.0", false, WriteOptions{}); return err }},
+		{"RestoreRelease", func() error { _, err := c.RestoreRelease(ctx, "1.0", WriteOptions{}); return err }},
+		{"AddReleaseJira", func() error { _, err := c.AddReleaseJira(ctx, "1.0", JiraInput{ID: "A-1"}, WriteOptions{}); return err }},
+		{"RemoveReleaseJira:A-1", func() error { _, err := c.RemoveReleaseJira(ctx, "1.0", "A-1", WriteOptions{}); return err }},
+		{"GetFilters", func() error { _, err := c.Filters(ctx, "android"); return err }},
+		{"GetVersions", func() error { _, err := c.Versions(ctx, "android", VersionQuery{}); return err }},
+		{"GetVersions", func() error { return readAll(c.ExportVersions(ctx, "android", VersionQuery{}, FormatNDJSON)) }},
+		{"GetJirasBetweenVersions", func() error { _, err := c.Jiras(ctx, "1.0", "2.0", DiffOptions{GAOnly: true}); return err }},
+		{"GetJirasBetweenVersions", func() error { return readAll(c.ExportJiras(ctx, "1.0", "2.0", DiffOptions{}, FormatCSV)) }},
+		{"SearchJiras", func() error { _, err := c.SearchJiras(ctx, JiraSearch{Query: "crash"}); return err }},
+		{"GetJira", func() error { _, err := c.Jira(ctx, "A-1"); return err }},
+		{"UpdateJira", func() error { _, err := c.UpdateJira(ctx, "A-1", JiraPatch{Title: &title}); return err }},
+		{"CompareVersions", func() error {
+			_, err := c.CompareVersions(ctx, VersionRef{Platform: "android", Version: "1.0"}, VersionRef{Platform: "ios", Version: "1.0"})
+			return err
+		}},
+		{"GetFeed", func() error { return readAll(c.Feed(ctx, "android", FeedAtom, FeedQuery{})) }},
+		{"CreateWebhook", func() error {
+			_, err := c.CreateWebhook(ctx, WebhookInput{Platform: "android", URL: "https://example.com"})
+			return err
+		}},
+		{"ListWebhooks", func() error { _, err := c.Webhooks(ctx, "android"); return err }},
+		{"DeadLetters", func() error { _, err := c.DeadLetters(ctx, DeliveryQuery{Platform: "android"}); return err }},
+		{"GetWebhook", func() error { _, err := c.Webhook(ctx, 1); return err }},
+		{"DeleteWebhook", func() error { return c.DeleteWebhook(ctx, 1) }},
+		{"ListWebhookDeliveries", func() error { _, err := c.WebhookDeliveries(ctx, 1, DeliveryQuery{}); return err }},
+		{"RetryWebhookDelivery", func() error { return c.RetryWebhookDelivery(ctx, 1) }},
+		{"GetTreeInfo", func() error { _, err := c.Tree(ctx, "android", TreeQuery{Depth: 2}); return err }},
+		{"CreateToken", func() error { _, err := c.CreateToken(ctx, TokenInput{Name: "ci", Role: "submitter"}); return err }},
+		{"ListTokens", func() error { _, err := c.Tokens(ctx); return err }},
+		{"RevokeToken", func() error { return c.RevokeToken(ctx, 1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if err := tt.call(); err != nil {
+				t.Fatalf("call failed: %v", err)
+			}
+			if got := svc.lastCall(); got != tt.want {
+				t.Fatalf("expected the handler to call %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSubmitRelease(t *testing.T) {
+	svc := &fakeService{}
+	c := New(newTestServer(t, svc).URL, WithToken("alice"))
+
+	sub := ReleaseSubmission{
+		Release: ReleaseInfo{Version: "1.0", Platform: "android"},
+		Changes: []JiraInput{{ID: "A-1", Title: "new"}},
+	}
+	res, err := c.SubmitRelease(context.Background(), sub, WriteOptions{IfMatch: `"r0"`, IdempotencyKey: "k1", Override: "hotfix"})
+	if err != nil {
+		t.Fatalf("SubmitRelease failed: %v", err)
+	}
+	if res.ETag != `"r1"` || !res.Replayed || len(res.Warnings) != 1 || res.Warnings[0].JiraID != "A-1" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if svc.sub.Release.Version != "1.0" || len(svc.sub.Changes) != 1 || svc.sub.Changes[0].Title != "new" {
+		t.Fatalf("submission not passed through: %+v", svc.sub)
+	}
+	want := service.WriteOptions{IfMatch: `"r0"`, IdempotencyKey: "k1", Override: "hotfix"}
+	if svc.opts != want || svc.actor != "alice" {
+		t.Fatalf("expected options %+v by alice, got %+v by %q", want, svc.opts, svc.actor)
+	}
+}
+
+func TestListPaging(t *testing.T) {
+	svc := &fakeService{}
+	c := New(newTestServer(t, svc).URL, WithToken("alice"))
+	ctx := context.Background()
+
+	list, err := c.ListReleases(ctx, "android", ListQuery{Sort: "version", Order: "desc", Limit: 10, Cursor: "abc"})
+	if err != nil {
+		t.Fatalf("ListReleases failed: %v", err)
+	}
+	if list.Total != 7 || list.NextCursor != "next" || len(list.Releases) != 1 {
+		t.Fatalf("unexpected page %+v", list)
+	}
+	if want := (service.ListQuery{Sort: "version", Order: "desc", Limit: 10, Cursor: "abc"}); svc.lq != want {
+		t.Fatalf("expected query %+v, got %+v", want, svc.lq)
+	}
+
+	since := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := c.Versions(ctx, "android", VersionQuery{Since: since, Statuses: []string{"GA", "beta"}}); err != nil {
+		t.Fatalf("Versions failed: %v", err)
+	}
+	if !svc.vq.Since.Equal(since) || strings.Join(svc.vq.Statuses, ",") != "GA,beta" {
+		t.Fatalf("version query not passed through: %+v", svc.vq)
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	svc := &fakeService{}
+	c := New(newTestServer(t, svc).URL, WithToken("alice"))
+	ctx := context.Background()
+
+	svc.err = &service.ValidationError{Details: []service.ValidationDetail{{Index: 1, ID: "A-2", Reason: "title too long"}}}
+	_, err := c.SubmitRelease(ctx, ReleaseSubmission{}, WriteOptions{})
+	var ve *ValidationError
+	if !errors.As(err, &ve) || len(ve.Details) != 1 || ve.Details[0].ID != "A-2" || ve.Details[0].Index != 1 {
+		t.Fatalf("expected validation details, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "validation failed" {
+		t.Fatalf("expected a 400 APIError, got %v", err)
+	}
+
+	tests := []struct {
+		err  error
+		want error
+	}{
+		{service.ErrNotFound, ErrNotFound},
+		{service.ErrPreconditionFailed, ErrPreconditionFailed},
+		{service.ErrIdempotencyConflict, ErrIdempotencyConflict},
+		{service.ErrReleaseLocked, ErrReleaseLocked},
+	}
+	for _, tt := range tests {
+		svc.err = tt.err
+		err := c.DeleteRelease(ctx, "1.0", WriteOptions{})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("expected %v, got %v", tt.want, err)
+		}
+		if errors.As(err, &ve) {
+			t.Errorf("%v should not carry validation details", err)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	svc := &fakeService{}
+	routes := newTestServer(t, svc).Config.Handler
+
+	// The first two requests of each test hit an overloaded proxy.
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		routes.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	ctx := context.Background()
+
+	c := New(srv.URL, WithToken("alice"), WithRetry(3, time.Millisecond))
+	if _, err := c.SubmitRelease(ctx, ReleaseSubmission{Release: ReleaseInfo{Version: "1.0"}}, WriteOptions{}); err != nil {
+		t.Fatalf("expected PUT to succeed on the third attempt, got %v", err)
+	}
+	if hits.Load() != 3 {
+		t.Fatalf("expected 3 attempts, got %d", hits.Load())
+	}
+
+	hits.Store(0)
+	_, err := c.RestoreRelease(ctx, "1.0", WriteOptions{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable || hits.Load() != 1 {
+		t.Fatalf("expected POST to fail without retrying, got %v after %d attempts", err, hits.Load())
+	}
+
+	hits.Store(0)
+	c = New(srv.URL, WithToken("alice"), WithRetry(2, time.Millisecond))
+	if _, err := c.Filters(ctx, "android"); !errors.As(err, &apiErr) || hits.Load() != 2 {
+		t.Fatalf("expected GET to give up after 2 attempts, got %v after %d", err, hits.Load())
+	}
+
+	hits.Store(0)
+	c = New(srv.URL, WithToken("alice"), WithRetry(3, time.Hour))
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Filters(ctx, "android"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the backoff to honour the context, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"jiraiya/internal/service"
+)
+
+// Errors an *APIError matches with errors.Is, by response status.
+var (
+	ErrNotFound            = service.ErrNotFound
+	ErrPreconditionFailed  = service.ErrPreconditionFailed
+	ErrIdempotencyConflict = service.ErrIdempotencyConflict
+	ErrReleaseLocked       = service.ErrReleaseLocked
+)
+
+type (
+	ValidationError  = service.ValidationError
+	ValidationDetail = service.ValidationDetail
+)
+
+// APIError is a non-2xx response from the server. Details is set when the
+// request failed validation; errors.As also unwraps it into a
+// *ValidationError.
+type APIError struct {
+	StatusCode int                `json:"-"`
+	Message    string             `json:"error"`
+	Details    []ValidationDetail `json:"details,omitempty"`
+}
+
+func (e *APIError) Error() string {
+	if len(e.Details) == 0 {
+		return fmt.Sprintf("api error %d: %s", e.StatusCode, e.Message)
+	}
+	reasons := make([]string, len(e.Details))
+	for i, d := range e.Details {
+		reasons[i] = d.Reason
+		if d.ID != "" {
+			reasons[i] = d.ID + ": " + d.Reason
+		}
+	}
+	return fmt.Sprintf("api error %d: %s: %s", e.StatusCode, e.Message, strings.Join(reasons, "; "))
+}
+
+// Is matches the service error the server reported with this status.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrPreconditionFailed:
+		return e.StatusCode == http.StatusPreconditionFailed
+	case ErrIdempotencyConflict:
+		return e.StatusCode == http.StatusUnprocessableEntity
+	case ErrReleaseLocked:
+		return e.StatusCode == http.StatusLocked
+	}
+	return false
+}
+
+// As unwraps a validation failure into a *ValidationError.
+func (e *APIError) As(target any) bool {
+	ve, ok := target.(**ValidationError)
+	if !ok || e.StatusCode != http.StatusBadRequest || len(e.Details) == 0 {
+		return false
+	}
+	*ve = &ValidationError{Details: e.Details}
+	return true
+}
+
+func decodeError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(data, apiErr); err != nil || apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(string(data))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+	}
+	return apiErr
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Feed formats accepted by Feed.
+const (
+	FeedAtom = "atom"
+	FeedRSS  = "rss"
+)
+
+// Jiras returns the jiras shipped after from up to and including to.
+func (c *Client) Jiras(ctx context.Context, from, to string, opts DiffOptions) ([]JiraOutput, error) {
+	var out []JiraOutput
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/jiras", query: diffParams(from, to, opts)}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExportJiras streams the jiras between two versions in format. The caller
+// must close the returned body.
+func (c *Client) ExportJiras(ctx context.Context, from, to string, opts DiffOptions, format string) (io.ReadCloser, error) {
+	q := diffParams(from, to, opts)
+	q.Set("format", format)
+	return c.stream(ctx, request{method: http.MethodGet, path: "/api/jiras", query: q})
+}
+
+// Jira returns a jira and the releases that link it.
+func (c *Client) Jira(ctx context.Context, id string) (*JiraDetail, error) {
+	var out JiraDetail
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/jiras/" + url.PathEscape(id)}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateJira changes a jira's metadata in every release that links it.
+func (c *Client) UpdateJira(ctx context.Context, id string, patch JiraPatch) (*JiraOutput, error) {
+	var out JiraOutput
+	req := request{method: http.MethodPatch, path: "/api/jiras/" + url.PathEscape(id), body: patch}
+	if _, err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchJiras returns one page of jiras matching js.
+func (c *Client) SearchJiras(ctx context.Context, js JiraSearch) (*JiraPage, error) {
+	q := url.Values{}
+	if js.Query != "" {
+		q.Set("q", js.Query)
+	}
+	if js.Domain != "" {
+		q.Set("domain", js.Domain)
+	}
+	if js.Impact != "" {
+		q.Set("impact", js.Impact)
+	}
+	pageParams(q, js.Limit, js.Offset)
+	var out JiraPage
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/jiras/search", query: q}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Filters returns the distinct domains and impacts of a platform's jiras.
+func (c *Client) Filters(ctx context.Context, platform string) (*Filters, error) {
+	var out Filters
+	q := url.Values{"platform": {platform}}
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/filters", query: q}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CompareVersions splits the jiras of two releases, possibly on different
+// platforms, into those only one side ships and those both ship.
+func (c *Client) CompareVersions(ctx context.Context, left, right VersionRef) (*Comparison, error) {
+	q := url.Values{
+		"left":  {left.Platform + ":" + left.Version},
+		"right": {right.Platform + ":" + right.Version},
+	}
+	var out Comparison
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/compare", query: q}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Feed streams a platform's changelog as an Atom or RSS document. The caller
+// must close the returned body.
+func (c *Client) Feed(ctx context.Context, platform, format string, fq FeedQuery) (io.ReadCloser, error) {
+	q := url.Values{}
+	if fq.Branch != "" {
+		q.Set("branch", fq.Branch)
+	}
+	if fq.Limit > 0 {
+		q.Set("limit", strconv.Itoa(fq.Limit))
+	}
+	path := "/api/feeds/" + url.PathEscape(platform+"."+format)
+	return c.stream(ctx, request{method: http.MethodGet, path: path, query: q})
+}
+
+func diffParams(from, to string, opts DiffOptions) url.Values {
+	q := url.Values{"from": {from}, "to": {to}}
+	if opts.AsOfTo {
+		q.Set("as_of", "to")
+	}
+	if opts.GAOnly {
+		q.Set("ga_only", "true")
+	}
+	return q
+}
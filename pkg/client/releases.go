@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"jiraiya/internal/service"
+)
+
+// Export formats accepted by the Export methods.
+const (
+	FormatJSON   = "json"
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+)
+
+func releasePath(version string, rest ...string) string {
+	p := "/api/releases/" + url.PathEscape(version)
+	for _, r := range rest {
+		p += "/" + r
+	}
+	return p
+}
+
+// SubmitRelease creates or replaces a release and its jiras.
+func (c *Client) SubmitRelease(ctx context.Context, sub ReleaseSubmission, opts WriteOptions) (*SubmitResult, error) {
+	return c.edit(ctx, request{method: http.MethodPut, path: "/api/releases", header: writeHeader(opts), body: sub})
+}
+
+// Releases returns the releases with the given version, narrowed to platform
+// if it is set. A single match carries its ETag.
+func (c *Client) Releases(ctx context.Context, version, platform string) ([]ReleaseOutput, error) {
+	q := url.Values{"version": {version}}
+	if platform != "" {
+		q.Set("platform", platform)
+	}
+	var out []ReleaseOutput
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: "/api/releases", query: q}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 1 {
+		out[0].ETag = resp.Header.Get("ETag")
+	}
+	return out, nil
+}
+
+// Release returns the release with the given version. It fails with
+// ErrNotFound if there is none.
+func (c *Client) Release(ctx context.Context, version string) (*ReleaseOutput, error) {
+	out, err := c.Releases(ctx, version, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+	return &out[0], nil
+}
+
+// ListReleases returns one page of a platform's releases.
+func (c *Client) ListReleases(ctx context.Context, platform string, lq ListQuery) (*ReleaseList, error) {
+	q := url.Values{"platform": {platform}}
+	listParams(q, lq)
+	list := &ReleaseList{}
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: "/api/releases", query: q}, &list.Releases)
+	if err != nil {
+		return nil, err
+	}
+	list.Total, list.NextCursor = pageHeaders(resp)
+	return list, nil
+}
+
+// ExportReleases streams every release of a platform in format. The caller
+// must close the returned body.
+func (c *Client) ExportReleases(ctx context.Context, platform, format string) (io.ReadCloser, error) {
+	q := url.Values{"platform": {platform}, "format": {format}}
+	return c.stream(ctx, request{method: http.MethodGet, path: "/api/releases", query: q})
+}
+
+// UpdateRelease changes a release's metadata.
+func (c *Client) UpdateRelease(ctx context.Context, version string, patch ReleasePatch, opts WriteOptions) (*ReleaseOutput, error) {
+	return c.releaseCall(ctx, request{method: http.MethodPatch, path: releasePath(version), header: writeHeader(opts), body: patch})
+}
+
+// DeleteRelease deletes a release. Deleted releases can be restored until
+// they are purged.
+func (c *Client) DeleteRelease(ctx context.Context, version string, opts WriteOptions) error {
+	_, err := c.do(ctx, request{method: http.MethodDelete, path: releasePath(version), header: writeHeader(opts)}, nil)
+	return err
+}
+
+// ReleaseHistory returns the audit trail of a release.
+func (c *Client) ReleaseHistory(ctx context.Context, version string) ([]AuditEntry, error) {
+	var out []AuditEntry
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: releasePath(version, "history")}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReleaseContents returns one page of the jiras a release ships, including
+// those inherited from its ancestors.
+func (c *Client) ReleaseContents(ctx context.Context, version string, cq ContentsQuery) (*JiraPage, error) {
+	q := url.Values{}
+	if cq.Domain != "" {
+		q.Set("domain", cq.Domain)
+	}
+	if cq.Impact != "" {
+		q.Set("impact", cq.Impact)
+	}
+	pageParams(q, cq.Limit, cq.Offset)
+	var out JiraPage
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: releasePath(version, "contents"), query: q}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// TransitionRelease moves a release to status.
+func (c *Client) TransitionRelease(ctx context.Context, version, status string, opts WriteOptions) (*ReleaseOutput, error) {
+	body := map[string]string{"status": status}
+	return c.releaseCall(ctx, request{method: http.MethodPost, path: releasePath(version, "transition"), header: writeHeader(opts), body: body})
+}
+
+// SetReleaseLock freezes or unfreezes a release.
+func (c *Client) SetReleaseLock(ctx context.Context, version string, locked bool, opts WriteOptions) (*ReleaseOutput, error) {
+	method := http.MethodPut
+	if !locked {
+		method = http.MethodDelete
+	}
+	return c.releaseCall(ctx, request{method: method, path: releasePath(version, "lock"), header: writeHeader(opts)})
+}
+
+// RestoreRelease brings back a deleted release that has not been purged.
+func (c *Client) RestoreRelease(ctx context.Context, version string, opts WriteOptions) (*ReleaseOutput, error) {
+	return c.releaseCall(ctx, request{method: http.MethodPost, path: releasePath(version, "restore"), header: writeHeader(opts)})
+}
+
+// AddReleaseJira links a single jira to a release.
+func (c *Client) AddReleaseJira(ctx context.Context, version string, j JiraInput, opts WriteOptions) (*SubmitResult, error) {
+	return c.edit(ctx, request{method: http.MethodPost, path: releasePath(version, "jiras"), header: writeHeader(opts), body: j})
+}
+
+// RemoveReleaseJira unlinks a single jira from a release.
+func (c *Client) RemoveReleaseJira(ctx context.Context, version, jiraID string, opts WriteOptions) (*SubmitResult, error) {
+	path := releasePath(version, "jiras", url.PathEscape(jiraID))
+	return c.edit(ctx, request{method: http.MethodDelete, path: path, header: writeHeader(opts)})
+}
+
+// Versions returns one page of a platform's versions.
+func (c *Client) Versions(ctx context.Context, platform string, vq VersionQuery) (*VersionList, error) {
+	list := &VersionList{}
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: "/api/versions", query: versionParams(platform, vq)}, &list.Versions)
+	if err != nil {
+		return nil, err
+	}
+	list.Total, list.NextCursor = pageHeaders(resp)
+	return list, nil
+}
+
+// ExportVersions streams the versions of a platform matching vq in format.
+// The caller must close the returned body.
+func (c *Client) ExportVersions(ctx context.Context, platform string, vq VersionQuery, format string) (io.ReadCloser, error) {
+	q := versionParams(platform, vq)
+	q.Set("format", format)
+	return c.stream(ctx, request{method: http.MethodGet, path: "/api/versions", query: q})
+}
+
+// Tree returns a platform's release tree, narrowed by tq.
+func (c *Client) Tree(ctx context.Context, platform string, tq TreeQuery) (*TreeInfo, error) {
+	q := url.Values{"platform": {platform}}
+	if tq.Root != "" {
+		q.Set("root", tq.Root)
+	}
+	if tq.Depth > 0 {
+		q.Set("depth", strconv.Itoa(tq.Depth))
+	}
+	var out TreeInfo
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/admin/tree", query: q}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// edit sends a submission or single-jira edit and reads its result.
+func (c *Client) edit(ctx context.Context, req request) (*SubmitResult, error) {
+	var out SubmitResult
+	resp, err := c.do(ctx, req, &out)
+	if err != nil {
+		return nil, err
+	}
+	out.ETag = resp.Header.Get("ETag")
+	out.Replayed = resp.Header.Get("Idempotent-Replayed") == "true"
+	return &out, nil
+}
+
+// releaseCall sends a request answered with the changed release.
+func (c *Client) releaseCall(ctx context.Context, req request) (*ReleaseOutput, error) {
+	var out ReleaseOutput
+	resp, err := c.do(ctx, req, &out)
+	if err != nil {
+		return nil, err
+	}
+	out.ETag = resp.Header.Get("ETag")
+	return &out, nil
+}
+
+func versionParams(platform string, vq VersionQuery) url.Values {
+	q := url.Values{"platform": {platform}}
+	if !vq.Since.IsZero() {
+		q.Set("since", vq.Since.Format(service.DateLayout))
+	}
+	if !vq.Until.IsZero() {
+		q.Set("until", vq.Until.Format(service.DateLayout))
+	}
+	if len(vq.Statuses) > 0 {
+		q.Set("status", strings.Join(vq.Statuses, ","))
+	}
+	listParams(q, vq.ListQuery)
+	return q
+}
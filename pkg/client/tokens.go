@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// CreateToken creates an API token. The returned Token is the only copy of
+// the secret; the server keeps just its hash.
+func (c *Client) CreateToken(ctx context.Context, in TokenInput) (*CreatedToken, error) {
+	var out CreatedToken
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/api/admin/tokens", body: in}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Tokens lists the API tokens, including revoked ones.
+func (c *Client) Tokens(ctx context.Context) ([]APIToken, error) {
+	var out []APIToken
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/admin/tokens"}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevokeToken revokes an API token. Requests using it fail from then on.
+func (c *Client) RevokeToken(ctx context.Context, id int64) error {
+	_, err := c.do(ctx, request{method: http.MethodDelete, path: "/api/admin/tokens/" + strconv.FormatInt(id, 10)}, nil)
+	return err
+}
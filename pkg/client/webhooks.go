@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func webhookPath(id int64, rest string) string {
+	return "/api/webhooks/" + strconv.FormatInt(id, 10) + rest
+}
+
+// CreateWebhook subscribes a URL to a platform's release events.
+func (c *Client) CreateWebhook(ctx context.Context, in WebhookInput) (*Webhook, error) {
+	var out Webhook
+	if _, err := c.do(ctx, request{method: http.MethodPost, path: "/api/webhooks", body: in}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Webhooks lists the webhook subscriptions, narrowed to platform if it is
+// set.
+func (c *Client) Webhooks(ctx context.Context, platform string) ([]Webhook, error) {
+	q := url.Values{}
+	if platform != "" {
+		q.Set("platform", platform)
+	}
+	var out []Webhook
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/webhooks", query: q}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Webhook returns a webhook subscription.
+func (c *Client) Webhook(ctx context.Context, id int64) (*Webhook, error) {
+	var out Webhook
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: webhookPath(id, "")}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhook unsubscribes a webhook.
+func (c *Client) DeleteWebhook(ctx context.Context, id int64) error {
+	_, err := c.do(ctx, request{method: http.MethodDelete, path: webhookPath(id, "")}, nil)
+	return err
+}
+
+// WebhookDeliveries returns the delivery log of a subscription. Only the
+// Status, Limit and Offset fields of dq apply.
+func (c *Client) WebhookDeliveries(ctx context.Context, id int64, dq DeliveryQuery) ([]WebhookDelivery, error) {
+	q := url.Values{}
+	if dq.Status != "" {
+		q.Set("status", dq.Status)
+	}
+	pageParams(q, dq.Limit, dq.Offset)
+	var out []WebhookDelivery
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: webhookPath(id, "/deliveries"), query: q}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeadLetters returns the deliveries that ran out of attempts. Only the
+// Platform, Limit and Offset fields of dq apply.
+func (c *Client) DeadLetters(ctx context.Context, dq DeliveryQuery) ([]WebhookDelivery, error) {
+	q := url.Values{}
+	if dq.Platform != "" {
+		q.Set("platform", dq.Platform)
+	}
+	pageParams(q, dq.Limit, dq.Offset)
+	var out []WebhookDelivery
+	if _, err := c.do(ctx, request{method: http.MethodGet, path: "/api/webhooks/dead-letters", query: q}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RetryWebhookDelivery requeues a dead delivery.
+func (c *Client) RetryWebhookDelivery(ctx context.Context, id int64) error {
+	path := "/api/webhooks/deliveries/" + strconv.FormatInt(id, 10) + "/retry"
+	_, err := c.do(ctx, request{method: http.MethodPost, path: path}, nil)
+	return err
+}
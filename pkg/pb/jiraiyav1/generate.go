@@ -0,0 +1,5 @@
+// Package jiraiyav1 holds the generated protobuf messages and gRPC stubs for
+// proto/jiraiya/v1/jiraiya.proto.
+package jiraiyav1
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=jiraiya --go-grpc_out=../../.. --go-grpc_opt=module=jiraiya jiraiya/v1/jiraiya.proto
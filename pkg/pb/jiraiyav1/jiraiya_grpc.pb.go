@@ -0,0 +1,298 @@
+// gRPC API for build systems that cannot speak REST. It covers the subset of
+// the HTTP API that submits and reads releases, plus a stream of release
+// events. Calls authenticate with an API token sent as "Bearer <token>" in
+// the "authorization" metadata key, as in the HTTP Authorization header.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: jiraiya/v1/jiraiya.proto
+
+package jiraiyav1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ReleaseService_SubmitRelease_FullMethodName           = "/jiraiya.v1.ReleaseService/SubmitRelease"
+	ReleaseService_DeleteRelease_FullMethodName           = "/jiraiya.v1.ReleaseService/DeleteRelease"
+	ReleaseService_GetVersions_FullMethodName             = "/jiraiya.v1.ReleaseService/GetVersions"
+	ReleaseService_GetJirasBetweenVersions_FullMethodName = "/jiraiya.v1.ReleaseService/GetJirasBetweenVersions"
+	ReleaseService_WatchReleases_FullMethodName           = "/jiraiya.v1.ReleaseService/WatchReleases"
+)
+
+// ReleaseServiceClient is the client API for ReleaseService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReleaseServiceClient interface {
+	// Creates or replaces a release and its jiras.
+	SubmitRelease(ctx context.Context, in *SubmitReleaseRequest, opts ...grpc.CallOption) (*SubmitReleaseResponse, error)
+	// Deletes a release. It can be restored over HTTP until it is purged.
+	DeleteRelease(ctx context.Context, in *DeleteReleaseRequest, opts ...grpc.CallOption) (*DeleteReleaseResponse, error)
+	// Returns one page of a platform's versions.
+	GetVersions(ctx context.Context, in *GetVersionsRequest, opts ...grpc.CallOption) (*GetVersionsResponse, error)
+	// Returns the jiras shipped after from_version up to and including
+	// to_version.
+	GetJirasBetweenVersions(ctx context.Context, in *GetJirasBetweenVersionsRequest, opts ...grpc.CallOption) (*GetJirasBetweenVersionsResponse, error)
+	// Streams release events as they are committed, in event id order. The
+	// stream ends when the client cancels or the server shuts down; reconnect
+	// with the last id seen to resume without gaps.
+	WatchReleases(ctx context.Context, in *WatchReleasesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReleaseEvent], error)
+}
+
+type releaseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReleaseServiceClient(cc grpc.ClientConnInterface) ReleaseServiceClient {
+	return &releaseServiceClient{cc}
+}
+
+func (c *releaseServiceClient) SubmitRelease(ctx context.Context, in *SubmitReleaseRequest, opts ...grpc.CallOption) (*SubmitReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitReleaseResponse)
+	err := c.cc.Invoke(ctx, ReleaseService_SubmitRelease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) DeleteRelease(ctx context.Context, in *DeleteReleaseRequest, opts ...grpc.CallOption) (*DeleteReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteReleaseResponse)
+	err := c.cc.Invoke(ctx, ReleaseService_DeleteRelease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) GetVersions(ctx context.Context, in *GetVersionsRequest, opts ...grpc.CallOption) (*GetVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVersionsResponse)
+	err := c.cc.Invoke(ctx, ReleaseService_GetVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) GetJirasBetweenVersions(ctx context.Context, in *GetJirasBetweenVersionsRequest, opts ...grpc.CallOption) (*GetJirasBetweenVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetJirasBetweenVersionsResponse)
+	err := c.cc.Invoke(ctx, ReleaseService_GetJirasBetweenVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) WatchReleases(ctx context.Context, in *WatchReleasesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReleaseEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ReleaseService_ServiceDesc.Streams[0], ReleaseService_WatchReleases_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchReleasesRequest, ReleaseEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReleaseService_WatchReleasesClient = grpc.ServerStreamingClient[ReleaseEvent]
+
+// ReleaseServiceServer is the server API for ReleaseService service.
+// All implementations must embed UnimplementedReleaseServiceServer
+// for forward compatibility.
+type ReleaseServiceServer interface {
+	// Creates or replaces a release and its jiras.
+	SubmitRelease(context.Context, *SubmitReleaseRequest) (*SubmitReleaseResponse, error)
+	// Deletes a release. It can be restored over HTTP until it is purged.
+	DeleteRelease(context.Context, *DeleteReleaseRequest) (*DeleteReleaseResponse, error)
+	// Returns one page of a platform's versions.
+	GetVersions(context.Context, *GetVersionsRequest) (*GetVersionsResponse, error)
+	// Returns the jiras shipped after from_version up to and including
+	// to_version.
+	GetJirasBetweenVersions(context.Context, *GetJirasBetweenVersionsRequest) (*GetJirasBetweenVersionsResponse, error)
+	// Streams release events as they are committed, in event id order. The
+	// stream ends when the client cancels or the server shuts down; reconnect
+	// with the last id seen to resume without gaps.
+	WatchReleases(*WatchReleasesRequest, grpc.ServerStreamingServer[ReleaseEvent]) error
+	mustEmbedUnimplementedReleaseServiceServer()
+}
+
+// UnimplementedReleaseServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReleaseServiceServer struct{}
+
+func (UnimplementedReleaseServiceServer) SubmitRelease(context.Context, *SubmitReleaseRequest) (*SubmitReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRelease not implemented")
+}
+func (UnimplementedReleaseServiceServer) DeleteRelease(context.Context, *DeleteReleaseRequest) (*DeleteReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRelease not implemented")
+}
+func (UnimplementedReleaseServiceServer) GetVersions(context.Context, *GetVersionsRequest) (*GetVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVersions not implemented")
+}
+func (UnimplementedReleaseServiceServer) GetJirasBetweenVersions(context.Context, *GetJirasBetweenVersionsRequest) (*GetJirasBetweenVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJirasBetweenVersions not implemented")
+}
+func (UnimplementedReleaseServiceServer) WatchReleases(*WatchReleasesRequest, grpc.ServerStreamingServer[ReleaseEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchReleases not implemented")
+}
+func (UnimplementedReleaseServiceServer) mustEmbedUnimplementedReleaseServiceServer() {}
+func (UnimplementedReleaseServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeReleaseServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReleaseServiceServer will
+// result in compilation errors.
+type UnsafeReleaseServiceServer interface {
+	mustEmbedUnimplementedReleaseServiceServer()
+}
+
+func RegisterReleaseServiceServer(s grpc.ServiceRegistrar, srv ReleaseServiceServer) {
+	// If the following call pancis, it indicates UnimplementedReleaseServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ReleaseService_ServiceDesc, srv)
+}
+
+func _ReleaseService_SubmitRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).SubmitRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReleaseService_SubmitRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).SubmitRelease(ctx, req.(*SubmitReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_DeleteRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).DeleteRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReleaseService_DeleteRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).DeleteRelease(ctx, req.(*DeleteReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_GetVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).GetVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReleaseService_GetVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).GetVersions(ctx, req.(*GetVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_GetJirasBetweenVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJirasBetweenVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).GetJirasBetweenVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReleaseService_GetJirasBetweenVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).GetJirasBetweenVersions(ctx, req.(*GetJirasBetweenVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_WatchReleases_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchReleasesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).WatchReleases(m, &grpc.GenericServerStream[WatchReleasesRequest, ReleaseEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ReleaseService_WatchReleasesServer = grpc.ServerStreamingServer[ReleaseEvent]
+
+// ReleaseService_ServiceDesc is the grpc.ServiceDesc for ReleaseService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ReleaseService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jiraiya.v1.ReleaseService",
+	HandlerType: (*ReleaseServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitRelease",
+			Handler:    _ReleaseService_SubmitRelease_Handler,
+		},
+		{
+			MethodName: "DeleteRelease",
+			Handler:    _ReleaseService_DeleteRelease_Handler,
+		},
+		{
+			MethodName: "GetVersions",
+			Handler:    _ReleaseService_GetVersions_Handler,
+		},
+		{
+			MethodName: "GetJirasBetweenVersions",
+			Handler:    _ReleaseService_GetJirasBetweenVersions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchReleases",
+			Handler:       _ReleaseService_WatchReleases_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "jiraiya/v1/jiraiya.proto",
+}
@@ -0,0 +1,52 @@
+-- name: InsertAPIToken :one
+INSERT INTO api_tokens (name, role, token_hash, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING id, name, role, token_hash, created_by, created_at, last_used_at, revoked_at;
+
+-- name: EnsureAPIToken :exec
+-- A revoked token stays revoked even if it is ensured again.
+INSERT INTO api_tokens (name, role, token_hash)
+VALUES ($1, $2, $3)
+ON CONFLICT (token_hash) DO NOTHING;
+
+-- name: GetAPITokenByHash :one
+SELECT id, name, role, token_hash, created_by, created_at, last_used_at, revoked_at
+FROM api_tokens
+WHERE token_hash = $1 AND revoked_at IS NULL;
+
+-- name: ListAPITokens :many
+SELECT id, name, role, token_hash, created_by, created_at, last_used_at, revoked_at
+FROM api_tokens
+ORDER BY id;
+
+-- name: RevokeAPIToken :execrows
+UPDATE api_tokens SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: TouchAPIToken :exec
+-- Records use at most once a minute so authentication stays read-mostly.
+UPDATE api_tokens SET last_used_at = now()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute');
+
+-- name: InsertAPITokenGrant :exec
+INSERT INTO api_token_grants (token_id, platform, role)
+VALUES ($1, $2, $3);
+
+-- name: EnsureAPITokenGrant :exec
+-- Gives an ensured token its role on every platform unless it already has
+-- grants.
+INSERT INTO api_token_grants (token_id, platform, role)
+SELECT t.id, '*', t.role
+FROM api_tokens t
+WHERE t.token_hash = $1
+  AND NOT EXISTS (SELECT 1 FROM api_token_grants g WHERE g.token_id = t.id);
+
+-- name: GetAPITokenGrants :many
+SELECT token_id, platform, role
+FROM api_token_grants
+WHERE token_id = $1
+ORDER BY platform;
+
+-- name: ListAPITokenGrants :many
+SELECT token_id, platform, role
+FROM api_token_grants
+ORDER BY token_id, platform;
@@ -0,0 +1,16 @@
+-- name: GetIdempotencyKey :one
+-- Expired keys are ignored until the purge job removes them.
+SELECT key, request_hash, response, created_at
+FROM idempotency_keys
+WHERE key = @key AND created_at >= @expired_before::timestamptz;
+
+-- name: InsertIdempotencyKey :execrows
+-- An expired key that has not been purged yet is taken over.
+INSERT INTO idempotency_keys (key, request_hash, response)
+VALUES (@key, @request_hash, @response)
+ON CONFLICT (key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash, response = EXCLUDED.response, created_at = now()
+WHERE idempotency_keys.created_at < @expired_before::timestamptz;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys WHERE created_at < $1;
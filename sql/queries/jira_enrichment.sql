@@ -0,0 +1,49 @@
+-- name: ListJirasToEnrich :many
+-- Jiras missing a wanted field whose cached lookup is absent or older than
+-- stale_before, or whose last lookup failed before retry_before.
+SELECT j.id
+FROM jiras j
+LEFT JOIN jira_enrichment e ON e.jira_id = j.id
+WHERE ((@want_title::bool AND j.title = '')
+    OR (@want_impact::bool AND j.impact = '')
+    OR (@want_domain::bool AND j.domain = '')
+    OR (@want_relnotes::bool AND j.relnotes = ''))
+  AND (e.jira_id IS NULL
+    OR (e.failed_at IS NULL AND e.fetched_at < @stale_before)
+    OR e.failed_at < @retry_before)
+ORDER BY j.id
+LIMIT @batch_size::int;
+
+-- name: UpsertJiraEnrichment :exec
+INSERT INTO jira_enrichment (jira_id, title, impact, domain, relnotes, found, fetched_at)
+VALUES ($1, $2, $3, $4, $5, $6, now())
+ON CONFLICT (jira_id) DO UPDATE SET
+    title = EXCLUDED.title,
+    impact = EXCLUDED.impact,
+    domain = EXCLUDED.domain,
+    relnotes = EXCLUDED.relnotes,
+    found = EXCLUDED.found,
+    fetched_at = EXCLUDED.fetched_at,
+    failed_at = NULL;
+
+-- name: MarkJiraEnrichmentFailed :exec
+-- Records a failed lookup. A cached earlier result is kept as it was.
+INSERT INTO jira_enrichment (jira_id, failed_at)
+VALUES ($1, now())
+ON CONFLICT (jira_id) DO UPDATE SET failed_at = EXCLUDED.failed_at;
+
+-- name: FillJiraFromEnrichment :one
+-- Copies cached values into the jira's empty fields only, so values set by a
+-- submission or PATCH always win. Returns no row if nothing was filled.
+UPDATE jiras j SET
+    title = CASE WHEN j.title = '' THEN e.title ELSE j.title END,
+    impact = CASE WHEN j.impact = '' THEN e.impact ELSE j.impact END,
+    domain = CASE WHEN j.domain = '' THEN e.domain ELSE j.domain END,
+    relnotes = CASE WHEN j.relnotes = '' THEN e.relnotes ELSE j.relnotes END
+FROM jira_enrichment e
+WHERE e.jira_id = j.id AND j.id = $1 AND e.found
+  AND ((j.title = '' AND e.title != '')
+    OR (j.impact = '' AND e.impact != '')
+    OR (j.domain = '' AND e.domain != '')
+    OR (j.relnotes = '' AND e.relnotes != ''))
+RETURNING j.id, j.title, j.impact, j.domain, j.relnotes;
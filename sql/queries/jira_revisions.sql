@@ -0,0 +1,9 @@
+-- name: InsertJiraRevision :exec
+INSERT INTO jira_revisions (jira_id, release_version, title, impact, domain, relnotes)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetJiraRevisionsAsOf :many
+SELECT DISTINCT ON (jira_id) jira_id, title, impact, domain, relnotes
+FROM jira_revisions
+WHERE jira_id = ANY(@ids::text[]) AND created_at <= @as_of::timestamptz
+ORDER BY jira_id, id DESC;
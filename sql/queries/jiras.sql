@@ -1,11 +1,13 @@
 -- name: UpsertJira :exec
+-- Empty submitted fields keep the stored value: CI usually submits bare ids,
+-- and those must not wipe metadata set earlier or filled in from Jira.
 INSERT INTO jiras (id, title, impact, domain, relnotes)
 VALUES ($1, $2, $3, $4, $5)
 ON CONFLICT (id) DO UPDATE SET
-    title = EXCLUDED.title,
-    impact = EXCLUDED.impact,
-    domain = EXCLUDED.domain,
-    relnotes = EXCLUDED.relnotes;
+    title = COALESCE(NULLIF(EXCLUDED.title, ''), jiras.title),
+    impact = COALESCE(NULLIF(EXCLUDED.impact, ''), jiras.impact),
+    domain = COALESCE(NULLIF(EXCLUDED.domain, ''), jiras.domain),
+    relnotes = COALESCE(NULLIF(EXCLUDED.relnotes, ''), jiras.relnotes);
 
 -- name: GetJirasByIDs :many
 SELECT id, title, impact, domain, relnotes
@@ -17,7 +19,7 @@ SELECT DISTINCT j.domain
 FROM jiras j
 JOIN release_jiras rj ON rj.jira_id = j.id
 JOIN releases r ON r.version = rj.release_version
-WHERE r.platform = $1 AND j.domain != ''
+WHERE r.platform = $1 AND r.deleted_at IS NULL AND j.domain != ''
 ORDER BY j.domain;
 
 -- name: GetDistinctImpacts :many
@@ -25,5 +27,55 @@ SELECT DISTINCT j.impact
 FROM jiras j
 JOIN release_jiras rj ON rj.jira_id = j.id
 JOIN releases r ON r.version = rj.release_version
-WHERE r.platform = $1 AND j.impact != ''
+WHERE r.platform = $1 AND r.deleted_at IS NULL AND j.impact != ''
 ORDER BY j.impact;
+
+-- name: GetJira :one
+SELECT id, title, impact, domain, relnotes
+FROM jiras
+WHERE id = $1;
+
+-- name: GetReleaseVersionsByJira :many
+-- Unless all_platforms is set, only releases on platforms are returned.
+SELECT r.version
+FROM releases r
+JOIN release_jiras rj ON rj.release_version = r.version
+WHERE rj.jira_id = @jira_id AND r.deleted_at IS NULL
+  AND (@all_platforms::bool OR r.platform = ANY(@platforms::text[]))
+ORDER BY r.version;
+
+-- name: GetReleaseVersionsByJiras :many
+-- Unless all_platforms is set, only releases on platforms are returned.
+SELECT rj.jira_id, r.version
+FROM releases r
+JOIN release_jiras rj ON rj.release_version = r.version
+WHERE rj.jira_id = ANY(@ids::text[]) AND r.deleted_at IS NULL
+  AND (@all_platforms::bool OR r.platform = ANY(@platforms::text[]))
+ORDER BY rj.jira_id, r.version;
+
+-- name: SearchJiras :many
+-- The text match must use the same expression as idx_jiras_fts for the
+-- index to apply.
+SELECT id, title, impact, domain, relnotes
+FROM jiras
+WHERE (@query::text = '' OR to_tsvector('english', title || ' ' || relnotes) @@ websearch_to_tsquery('english', @query::text))
+  AND (@domain::text = '' OR domain = @domain::text)
+  AND (@impact::text = '' OR impact = @impact::text)
+ORDER BY ts_rank(to_tsvector('english', title || ' ' || relnotes), websearch_to_tsquery('english', @query::text)) DESC, id
+LIMIT @page_limit::int OFFSET @page_offset::int;
+
+-- name: CountJiras :one
+SELECT count(*)
+FROM jiras
+WHERE (@query::text = '' OR to_tsvector('english', title || ' ' || relnotes) @@ websearch_to_tsquery('english', @query::text))
+  AND (@domain::text = '' OR domain = @domain::text)
+  AND (@impact::text = '' OR impact = @impact::text);
+
+-- name: UpdateJira :one
+UPDATE jiras SET
+    title = COALESCE(sqlc.narg('title'), title),
+    impact = COALESCE(sqlc.narg('impact'), impact),
+    domain = COALESCE(sqlc.narg('domain'), domain),
+    relnotes = COALESCE(sqlc.narg('relnotes'), relnotes)
+WHERE id = @id
+RETURNING id, title, impact, domain, relnotes;
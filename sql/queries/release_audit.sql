@@ -0,0 +1,9 @@
+-- name: InsertReleaseAudit :exec
+INSERT INTO release_audit (release_version, platform, action, actor, payload, added_jiras, removed_jiras)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetReleaseAudit :many
+SELECT id, release_version, platform, action, actor, payload, added_jiras, removed_jiras, created_at
+FROM release_audit
+WHERE release_version = $1
+ORDER BY id;
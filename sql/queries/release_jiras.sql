@@ -3,6 +3,9 @@ INSERT INTO release_jiras (release_version, jira_id)
 VALUES ($1, $2)
 ON CONFLICT DO NOTHING;
 
+-- name: UnlinkJiraFromRelease :execrows
+DELETE FROM release_jiras WHERE release_version = $1 AND jira_id = $2;
+
 -- name: UnlinkJirasFromRelease :exec
 DELETE FROM release_jiras WHERE release_version = $1;
 
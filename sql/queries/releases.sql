@@ -1,31 +1,189 @@
 -- name: UpsertRelease :exec
-INSERT INTO releases (version, from_ver, platform, release_date, submitted_by, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, now(), now())
+-- A submission for a soft-deleted version recreates it: the old status and
+-- lock are discarded along with the deletion marker.
+INSERT INTO releases (version, from_ver, platform, release_date, submitted_by, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, now(), now())
 ON CONFLICT (version) DO UPDATE SET
     from_ver = EXCLUDED.from_ver,
     platform = EXCLUDED.platform,
     release_date = EXCLUDED.release_date,
     submitted_by = EXCLUDED.submitted_by,
+    status = CASE WHEN releases.deleted_at IS NULL THEN releases.status ELSE EXCLUDED.status END,
+    locked = releases.locked AND releases.deleted_at IS NULL,
+    deleted_at = NULL,
     updated_at = now();
 
 -- name: GetRelease :one
-SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
 FROM releases
-WHERE version = $1;
+WHERE version = $1 AND deleted_at IS NULL;
 
--- name: DeleteRelease :exec
-DELETE FROM releases WHERE version = $1;
+-- name: GetReleasesByVersions :many
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE version = ANY(@versions::text[]) AND deleted_at IS NULL;
+
+-- name: LockRelease :one
+-- Unlike GetRelease this also returns soft-deleted rows, so restores and
+-- re-submissions can lock them.
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE version = $1
+FOR UPDATE;
+
+-- name: UpdateReleaseStatus :exec
+UPDATE releases SET status = $2, updated_at = now() WHERE version = $1;
+
+-- name: SetReleaseLocked :exec
+UPDATE releases SET locked = $2, updated_at = now() WHERE version = $1;
+
+-- name: GetReleaseStatusesByPlatform :many
+SELECT version, status FROM releases WHERE platform = $1 AND deleted_at IS NULL;
+
+-- name: SoftDeleteRelease :exec
+UPDATE releases SET deleted_at = now(), updated_at = now() WHERE version = $1;
 
--- name: GetVersionsByPlatform :many
-SELECT version, from_ver, release_date, submitted_by, created_at, updated_at
+-- name: RestoreRelease :exec
+UPDATE releases SET deleted_at = NULL, updated_at = now() WHERE version = $1;
+
+-- name: PurgeDeletedReleases :many
+DELETE FROM releases
+WHERE deleted_at < @deleted_before::timestamptz
+RETURNING version, platform;
+
+-- name: ListReleasesByVersion :many
+-- Keyset page over a platform's live releases by version. since, until and
+-- statuses narrow the versions list; the releases list leaves them empty.
+-- Each order has its own branch so it reads idx_releases_platform_version in
+-- index order; only the requested one returns rows.
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR version > @after_version::text)
+ORDER BY version
+LIMIT @page_limit::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR version < @after_version::text)
+ORDER BY version DESC
+LIMIT @page_limit::int);
+
+-- name: ListReleasesByReleaseDate :many
+-- Like ListReleasesByVersion, by release date with version breaking ties.
+-- Undated releases come last in either order: each branch sorts a NULL date
+-- past the end it reads towards, using the expression its
+-- idx_releases_platform_date_* index is built on.
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR (COALESCE(release_date, 'infinity'::date), version) > (COALESCE(sqlc.narg('after_date')::date, 'infinity'::date), @after_version::text))
+ORDER BY COALESCE(release_date, 'infinity'::date), version
+LIMIT @page_limit::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
 FROM releases
-WHERE platform = $1
-ORDER BY release_date DESC;
+WHERE @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR (COALESCE(release_date, '-infinity'::date), version) < (COALESCE(sqlc.narg('after_date')::date, '-infinity'::date), @after_version::text))
+ORDER BY COALESCE(release_date, '-infinity'::date) DESC, version DESC
+LIMIT @page_limit::int);
+
+-- name: ListReleasesByCreatedAt :many
+-- Like ListReleasesByVersion, by creation time with version breaking ties.
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR (created_at, version) > (@after_time::timestamptz, @after_version::text))
+ORDER BY created_at, version
+LIMIT @page_limit::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR (created_at, version) < (@after_time::timestamptz, @after_version::text))
+ORDER BY created_at DESC, version DESC
+LIMIT @page_limit::int);
+
+-- name: ListReleasesByUpdatedAt :many
+-- Like ListReleasesByVersion, by last update with version breaking ties.
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE NOT @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR (updated_at, version) > (@after_time::timestamptz, @after_version::text))
+ORDER BY updated_at, version
+LIMIT @page_limit::int)
+UNION ALL
+(SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
+FROM releases
+WHERE @descending::bool
+  AND platform = @platform AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]))
+  AND (@after_version::text = '' OR (updated_at, version) < (@after_time::timestamptz, @after_version::text))
+ORDER BY updated_at DESC, version DESC
+LIMIT @page_limit::int);
+
+-- name: CountVersionsByPlatform :one
+SELECT count(*)
+FROM releases
+WHERE platform = @platform
+  AND deleted_at IS NULL
+  AND (sqlc.narg('since')::date IS NULL OR release_date >= sqlc.narg('since')::date)
+  AND (sqlc.narg('until')::date IS NULL OR release_date <= sqlc.narg('until')::date)
+  AND (cardinality(@statuses::text[]) = 0 OR status = ANY(@statuses::text[]));
 
 -- name: GetAllReleasesByPlatform :many
-SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at
+SELECT version, from_ver, platform, release_date, submitted_by, created_at, updated_at, status, locked, deleted_at
 FROM releases
-WHERE platform = $1;
+WHERE platform = $1 AND deleted_at IS NULL;
+
+-- name: CountReleasesByPlatform :one
+SELECT count(*) FROM releases WHERE platform = $1 AND deleted_at IS NULL;
 
 -- name: GetAllPlatforms :many
-SELECT DISTINCT platform FROM releases WHERE platform != '' ORDER BY platform;
+SELECT DISTINCT platform FROM releases WHERE platform != '' AND deleted_at IS NULL ORDER BY platform;
+
+-- name: GetReleaseDateRejects :many
+SELECT version, raw_value, recorded_at
+FROM release_date_rejects
+ORDER BY version;
+
+-- name: TouchRelease :exec
+UPDATE releases SET updated_at = now() WHERE version = $1;
+
+-- name: UpdateReleaseMetadata :exec
+UPDATE releases SET
+    release_date = CASE WHEN @set_release_date::bool THEN sqlc.narg('release_date')::date ELSE release_date END,
+    submitted_by = COALESCE(sqlc.narg('submitted_by'), submitted_by),
+    updated_at = now()
+WHERE version = @version;
@@ -0,0 +1,108 @@
+-- name: InsertWebhookSubscription :one
+INSERT INTO webhook_subscriptions (platform, url, secret, events)
+VALUES ($1, $2, $3, $4)
+RETURNING id, platform, url, secret, events, created_at;
+
+-- name: GetWebhookSubscription :one
+SELECT id, platform, url, secret, events, created_at
+FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT id, platform, url, secret, events, created_at
+FROM webhook_subscriptions
+WHERE (@platform::text = '' OR platform = @platform::text)
+  AND (@all_platforms::bool OR platform = ANY(@platforms::text[]))
+ORDER BY id;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: LockWebhookEvents :exec
+-- Held shared by every transaction that inserts an event, from before its id
+-- is drawn until it ends. Inserts do not wait for each other; see
+-- WaitWebhookEvents.
+SELECT pg_advisory_xact_lock_shared(hashtext('webhook_events'));
+
+-- name: WaitWebhookEvents :exec
+-- Waits out every transaction that has drawn an event id but not yet ended,
+-- and holds off new ones until the caller's transaction ends. Readers
+-- following the log by id take it before reading, so an id below one they
+-- have seen can never commit late.
+SELECT pg_advisory_xact_lock(hashtext('webhook_events'));
+
+-- name: InsertWebhookEvent :one
+INSERT INTO webhook_events (platform, event_type, release_version, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+
+-- name: GetLatestWebhookEventID :one
+SELECT COALESCE(MAX(id), 0)::bigint FROM webhook_events;
+
+-- name: ListWebhookEvents :many
+-- Reads the outbox in id order after after_id, for clients that follow
+-- release events as a stream. Unless all_platforms is set, only events on
+-- platforms are returned.
+SELECT id, platform, event_type, release_version, payload, created_at
+FROM webhook_events
+WHERE id > @after_id::bigint
+  AND (@platform::text = '' OR platform = @platform::text)
+  AND (@all_platforms::bool OR platform = ANY(@platforms::text[]))
+ORDER BY id
+LIMIT @page_limit::int;
+
+-- name: QueueWebhookDeliveries :execrows
+-- Fans an outbox event out to every subscription on its platform that listens
+-- for the event type. An empty events list listens for everything.
+INSERT INTO webhook_deliveries (event_id, subscription_id)
+SELECT e.id, s.id
+FROM webhook_events e
+JOIN webhook_subscriptions s ON s.platform = e.platform
+WHERE e.id = $1 AND (cardinality(s.events) = 0 OR e.event_type = ANY(s.events));
+
+-- name: ClaimWebhookDeliveries :many
+-- Leases due deliveries by pushing next_attempt_at out to lease_until, so a
+-- worker that dies mid-delivery only delays the retry.
+WITH due AS (
+    SELECT id FROM webhook_deliveries
+    WHERE status = 'pending' AND next_attempt_at <= now()
+    ORDER BY next_attempt_at, id
+    LIMIT @batch_size::int
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE webhook_deliveries d
+SET attempts = d.attempts + 1, next_attempt_at = @lease_until, updated_at = now()
+FROM due, webhook_events e, webhook_subscriptions s
+WHERE d.id = due.id AND e.id = d.event_id AND s.id = d.subscription_id
+RETURNING d.id, d.event_id, d.attempts, e.event_type, e.payload, s.url, s.secret;
+
+-- name: RecordWebhookAttempt :exec
+UPDATE webhook_deliveries
+SET status = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5,
+    delivered_at = $6, updated_at = now()
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT d.id, d.event_id, d.subscription_id, s.platform, e.event_type, e.release_version,
+       d.status, d.attempts, d.next_attempt_at, d.last_status_code, d.last_error,
+       d.delivered_at, d.created_at
+FROM webhook_deliveries d
+JOIN webhook_events e ON e.id = d.event_id
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+WHERE (@subscription_id::bigint = 0 OR d.subscription_id = @subscription_id::bigint)
+  AND (@platform::text = '' OR s.platform = @platform::text)
+  AND (@status::text = '' OR d.status = @status::text)
+  AND (@all_platforms::bool OR s.platform = ANY(@platforms::text[]))
+ORDER BY d.id DESC
+LIMIT @page_limit::int OFFSET @page_offset::int;
+
+-- name: GetWebhookDeliveryPlatform :one
+SELECT s.platform
+FROM webhook_deliveries d
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+WHERE d.id = $1;
+
+-- name: RetryWebhookDelivery :execrows
+UPDATE webhook_deliveries
+SET status = 'pending', attempts = 0, next_attempt_at = now(), updated_at = now()
+WHERE id = $1 AND status = 'dead';
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS release_audit (
+    id BIGSERIAL PRIMARY KEY,
+    release_version TEXT NOT NULL,
+    platform TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    actor TEXT NOT NULL DEFAULT '',
+    payload JSONB,
+    added_jiras TEXT[] NOT NULL DEFAULT '{}',
+    removed_jiras TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_release_audit_version ON release_audit(release_version, id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS jira_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    jira_id TEXT NOT NULL REFERENCES jiras(id) ON DELETE CASCADE,
+    release_version TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    impact TEXT NOT NULL DEFAULT '',
+    domain TEXT NOT NULL DEFAULT '',
+    relnotes TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_jira_revisions_jira_id ON jira_revisions(jira_id, id);
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS release_date_rejects (
+    version TEXT PRIMARY KEY,
+    raw_value TEXT NOT NULL,
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Normalize free-form text dates before converting the column. Values that do
+-- not parse are recorded in release_date_rejects and cleared. The conversion
+-- rewrites the table, so it only runs while the column is still text.
+DO $$
+DECLARE
+    r RECORD;
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'releases' AND column_name = 'release_date' AND data_type = 'text'
+    ) THEN
+        FOR r IN SELECT version, release_date FROM releases WHERE btrim(release_date) != '' LOOP
+            BEGIN
+                UPDATE releases SET release_date = to_char(btrim(r.release_date)::date, 'YYYY-MM-DD')
+                WHERE version = r.version;
+            EXCEPTION WHEN others THEN
+                INSERT INTO release_date_rejects (version, raw_value) VALUES (r.version, r.release_date)
+                ON CONFLICT (version) DO UPDATE SET raw_value = EXCLUDED.raw_value, recorded_at = now();
+                UPDATE releases SET release_date = '' WHERE version = r.version;
+            END;
+        END LOOP;
+
+        ALTER TABLE releases ALTER COLUMN release_date DROP DEFAULT;
+        ALTER TABLE releases ALTER COLUMN release_date DROP NOT NULL;
+        ALTER TABLE releases ALTER COLUMN release_date TYPE DATE USING NULLIF(release_date, '')::date;
+    END IF;
+END
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_releases_platform_date ON releases(platform, release_date);
@@ -0,0 +1,12 @@
+ALTER TABLE releases ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'ga';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'releases_status_check') THEN
+        ALTER TABLE releases ADD CONSTRAINT releases_status_check
+            CHECK (status IN ('draft', 'rc', 'ga', 'withdrawn'));
+    END IF;
+END
+$$;
+
+CREATE INDEX IF NOT EXISTS idx_releases_platform_status ON releases(platform, status);
@@ -0,0 +1,2 @@
+ALTER TABLE releases ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_releases_deleted_at ON releases(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,4 @@
+CREATE INDEX IF NOT EXISTS idx_jiras_fts ON jiras
+    USING GIN (to_tsvector('english', title || ' ' || relnotes));
+CREATE INDEX IF NOT EXISTS idx_jiras_domain ON jiras(domain);
+CREATE INDEX IF NOT EXISTS idx_jiras_impact ON jiras(impact);
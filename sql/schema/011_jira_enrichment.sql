@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS jira_enrichment (
+    jira_id TEXT PRIMARY KEY REFERENCES jiras(id) ON DELETE CASCADE,
+    title TEXT NOT NULL DEFAULT '',
+    impact TEXT NOT NULL DEFAULT '',
+    domain TEXT NOT NULL DEFAULT '',
+    relnotes TEXT NOT NULL DEFAULT '',
+    found BOOLEAN NOT NULL DEFAULT false,
+    fetched_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Set when the last lookup failed; such jiras are retried after a short
+-- backoff instead of stalling every later pass.
+ALTER TABLE jira_enrichment ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
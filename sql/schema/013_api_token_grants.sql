@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS api_token_grants (
+    token_id BIGINT NOT NULL REFERENCES api_tokens(id) ON DELETE CASCADE,
+    platform TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('reader', 'submitter', 'admin')),
+    PRIMARY KEY (token_id, platform)
+);
+
+-- Tokens from before grants keep their role on every platform. Every token
+-- created since has at least one grant, so this only touches old ones.
+INSERT INTO api_token_grants (token_id, platform, role)
+SELECT t.id, '*', t.role
+FROM api_tokens t
+WHERE NOT EXISTS (SELECT 1 FROM api_token_grants g WHERE g.token_id = t.id);
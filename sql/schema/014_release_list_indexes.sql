@@ -0,0 +1,16 @@
+-- Indexes for the keyset release lists, one per sort. Each leads with the
+-- platform and ends with version, the tie breaker, so a page reads from the
+-- cursor in index order in either direction.
+CREATE INDEX IF NOT EXISTS idx_releases_platform_version ON releases(platform, version)
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_releases_platform_created_at ON releases(platform, created_at, version)
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_releases_platform_updated_at ON releases(platform, updated_at, version)
+    WHERE deleted_at IS NULL;
+
+-- Undated releases sort last in both orders, so ascending and descending
+-- lists need a NULL date at opposite ends of the index.
+CREATE INDEX IF NOT EXISTS idx_releases_platform_date_asc ON releases(platform, COALESCE(release_date, 'infinity'::date), version)
+    WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_releases_platform_date_desc ON releases(platform, COALESCE(release_date, '-infinity'::date), version)
+    WHERE deleted_at IS NULL;
@@ -6,3 +6,87 @@ import _ "embed"
 //
 //go:embed 001_init.sql
 var InitSQL string
+
+// ReleaseAuditSQL adds the append-only release audit table.
+//
+//go:embed 002_release_audit.sql
+var ReleaseAuditSQL string
+
+// JiraRevisionsSQL adds per-submission jira metadata revisions.
+//
+//go:embed 003_jira_revisions.sql
+var JiraRevisionsSQL string
+
+// IdempotencyKeysSQL adds stored responses for idempotent submissions.
+//
+//go:embed 004_idempotency_keys.sql
+var IdempotencyKeysSQL string
+
+// ReleaseDateSQL converts release_date from free-form text to DATE.
+//
+//go:embed 005_release_date.sql
+var ReleaseDateSQL string
+
+// ReleaseStatusSQL adds the release lifecycle status.
+//
+//go:embed 006_release_status.sql
+var ReleaseStatusSQL string
+
+// ReleaseLockSQL adds the manual release freeze flag.
+//
+//go:embed 007_release_lock.sql
+var ReleaseLockSQL string
+
+// ReleaseSoftDeleteSQL adds soft deletion of releases.
+//
+//go:embed 008_release_soft_delete.sql
+var ReleaseSoftDeleteSQL string
+
+// JiraSearchSQL adds the full-text and filter indexes for jira search.
+//
+//go:embed 009_jira_search.sql
+var JiraSearchSQL string
+
+// WebhooksSQL adds webhook subscriptions, the event outbox and deliveries.
+//
+//go:embed 010_webhooks.sql
+var WebhooksSQL string
+
+// JiraEnrichmentSQL adds the cache of jira metadata fetched from Jira.
+//
+//go:embed 011_jira_enrichment.sql
+var JiraEnrichmentSQL string
+
+// APITokensSQL adds hashed API tokens and their roles.
+//
+//go:embed 012_api_tokens.sql
+var APITokensSQL string
+
+// APITokenGrantsSQL adds per-platform roles for API tokens.
+//
+//go:embed 013_api_token_grants.sql
+var APITokenGrantsSQL string
+
+// ReleaseListIndexesSQL adds the indexes behind the keyset release lists.
+//
+//go:embed 014_release_list_indexes.sql
+var ReleaseListIndexesSQL string
+
+// Migrations lists every schema file in the order it must be applied. Each
+// file is idempotent, so the full list is safe to re-run on every deploy.
+var Migrations = []string{
+	InitSQL,
+	ReleaseAuditSQL,
+	JiraRevisionsSQL,
+	IdempotencyKeysSQL,
+	ReleaseDateSQL,
+	ReleaseStatusSQL,
+	ReleaseLockSQL,
+	ReleaseSoftDeleteSQL,
+	JiraSearchSQL,
+	WebhooksSQL,
+	JiraEnrichmentSQL,
+	APITokensSQL,
+	APITokenGrantsSQL,
+	ReleaseListIndexesSQL,
+}
@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"testing"
+
+	"jiraiya/internal/service"
+)
+
+func TestReleaseHistory(t *testing.T) {
+	env := setup(t)
+
+	env.as(t, "alice", service.RoleSubmitter).put(t, "/api/releases", map[string]any{
+		"release": map[string]string{
+			"version": "3.0.0", "from_ver": "", "platform": "ios",
+			"release_date": "2026-03-01", "submitted_by": "alice",
+		},
+		"changes": []map[string]string{
+			{"id": "H-1", "title": "One"},
+			{"id": "H-2", "title": "Two"},
+		},
+	})
+	env.as(t, "bob", service.RoleSubmitter).put(t, "/api/releases", map[string]any{
+		"release": map[string]string{
+			"version": "3.0.0", "from_ver": "", "platform": "ios",
+			"release_date": "2026-03-01", "submitted_by": "bob",
+		},
+		"changes": []map[string]string{
+			{"id": "H-2", "title": "Two"},
+			{"id": "H-3", "title": "Three"},
+		},
+	})
+
+	if code, body := env.as(t, "carol", service.RoleAdmin).delete(t, "/api/releases/3.0.0"); code != 200 {
+		t.Fatalf("delete: expected 200, got %d: %s", code, body)
+	}
+
+	code, body := env.get(t, "/api/releases/3.0.0/history")
+	if code != 200 {
+		t.Fatalf("expected 200, got %d: %s", code, body)
+	}
+	history := decode[[]struct {
+		Action  string         `json:"action"`
+		Actor   string         `json:"actor"`
+		Payload map[string]any `json:"payload"`
+		Added   []string       `json:"added"`
+		Removed []string       `json:"removed"`
+	}](t, body)
+	if len(history) != 3 {
+		t.Fatalf("expected 3 audit entries, got %d: %s", len(history), body)
+	}
+
+	first, second, third := history[0], history[1], history[2]
+	if first.Action != "submit" || first.Actor != "alice" || len(first.Added) != 2 || len(first.Removed) != 0 {
+		t.Fatalf("unexpected first entry: %+v", first)
+	}
+	if first.Payload == nil {
+		t.Fatal("expected submitted payload on first entry")
+	}
+	if second.Actor != "bob" || len(second.Added) != 1 || second.Added[0] != "H-3" ||
+		len(second.Removed) != 1 || second.Removed[0] != "H-1" {
+		t.Fatalf("unexpected second entry: %+v", second)
+	}
+	if third.Action != "delete" || third.Actor != "carol" || len(third.Removed) != 2 {
+		t.Fatalf("unexpected third entry: %+v", third)
+	}
+}
@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"jiraiya/internal/service"
+)
+
+func TestAuth(t *testing.T) {
+	env := setup(t)
+
+	t.Run("requires a token", func(t *testing.T) {
+		for _, auth := range []string{"Bearer ", "Bearer jy_unknown"} {
+			resp := env.do(t, http.MethodGet, "/api/versions?platform=ios", nil, http.Header{"Authorization": {auth}})
+			if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+				t.Fatalf("%q: expected 401, got %d", auth, resp.StatusCode)
+			}
+		}
+		resp := env.do(t, http.MethodGet, "/api/openapi.json", nil, http.Header{"Authorization": nil})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("openapi.json: expected 200 without a token, got %d", resp.StatusCode)
+		}
+	})
+
+	// Tokens are created over the API like an operator would.
+	resp := env.do(t, http.MethodPost, "/api/admin/tokens", map[string]string{"name": "mobile-ci", "role": "submitter"}, nil)
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create token: expected 201, got %d: %s", resp.StatusCode, body)
+	}
+	created := decode[service.CreatedToken](t, body)
+	if created.Token == "" || created.Role != service.RoleSubmitter || created.CreatedBy != "test" {
+		t.Fatalf("unexpected token %+v", created)
+	}
+	submitter := *env
+	submitter.token = created.Token
+	reader := env.as(t, "dashboard", service.RoleReader)
+
+	submission := map[string]any{
+		"release": map[string]string{"version": "1.0.0", "platform": "ios", "submitted_by": "someone-else"},
+		"changes": []map[string]string{{"id": "AUTH-1"}},
+	}
+
+	t.Run("roles", func(t *testing.T) {
+		if code, body := reader.put(t, "/api/releases", submission); code != http.StatusForbidden {
+			t.Fatalf("reader submit: expected 403, got %d: %s", code, body)
+		}
+		if code, body := submitter.put(t, "/api/releases", submission); code != http.StatusOK {
+			t.Fatalf("submitter submit: expected 200, got %d: %s", code, body)
+		}
+		if code, body := reader.get(t, "/api/releases?version=1.0.0"); code != http.StatusOK {
+			t.Fatalf("reader get: expected 200, got %d: %s", code, body)
+		}
+		if code, _ := submitter.delete(t, "/api/releases/1.0.0"); code != http.StatusForbidden {
+			t.Fatalf("submitter delete: expected 403, got %d", code)
+		}
+		if code, _ := submitter.get(t, "/api/admin/tree?platform=ios"); code != http.StatusForbidden {
+			t.Fatalf("submitter tree: expected 403, got %d", code)
+		}
+		if code, _ := submitter.get(t, "/api/admin/tokens"); code != http.StatusForbidden {
+			t.Fatalf("submitter tokens: expected 403, got %d", code)
+		}
+	})
+
+	t.Run("submitter comes from the token", func(t *testing.T) {
+		_, body := env.get(t, "/api/releases?version=1.0.0")
+		if got := decode[[]service.ReleaseOutput](t, body); got[0].SubmittedBy != "mobile-ci" {
+			t.Fatalf("expected submitted_by mobile-ci, got %q", got[0].SubmittedBy)
+		}
+		_, body = env.get(t, "/api/releases/1.0.0/history")
+		if got := decode[[]service.AuditEntry](t, body); got[0].Actor != "mobile-ci" {
+			t.Fatalf("expected actor mobile-ci, got %q", got[0].Actor)
+		}
+
+		resp := submitter.do(t, http.MethodPatch, "/api/releases/1.0.0", map[string]string{"submitted_by": "someone-else"}, nil)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("submitter reattribute: expected 403, got %d", resp.StatusCode)
+		}
+		if resp := env.do(t, http.MethodPatch, "/api/releases/1.0.0", map[string]string{"submitted_by": "release-eng"}, nil); resp.StatusCode != http.StatusOK {
+			t.Fatalf("admin reattribute: expected 200, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("only admins override locks", func(t *testing.T) {
+		if resp := env.do(t, http.MethodPut, "/api/releases/1.0.0/lock", nil, nil); resp.StatusCode != http.StatusOK {
+			t.Fatalf("lock: expected 200, got %d", resp.StatusCode)
+		}
+		override := http.Header{"X-Admin-Override": {"hotfix"}}
+		if resp := submitter.do(t, http.MethodPut, "/api/releases", submission, override); resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("submitter override: expected 403, got %d", resp.StatusCode)
+		}
+		if resp := env.do(t, http.MethodPut, "/api/releases", submission, override); resp.StatusCode != http.StatusOK {
+			t.Fatalf("admin override: expected 200, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		_, body := env.get(t, "/api/admin/tokens")
+		tokens := decode[[]map[string]any](t, body)
+		if len(tokens) != 3 {
+			t.Fatalf("expected 3 tokens, got %d: %s", len(tokens), body)
+		}
+		for _, tok := range tokens {
+			if _, ok := tok["token"]; ok {
+				t.Fatalf("listed token exposes its secret: %v", tok)
+			}
+		}
+
+		if code, body := env.delete(t, "/api/admin/tokens/"+strconv.FormatInt(created.ID, 10)); code != http.StatusOK {
+			t.Fatalf("revoke: expected 200, got %d: %s", code, body)
+		}
+		if code, _ := submitter.get(t, "/api/versions?platform=ios"); code != http.StatusUnauthorized {
+			t.Fatalf("revoked token: expected 401, got %d", code)
+		}
+		if code, _ := env.delete(t, "/api/admin/tokens/"+strconv.FormatInt(created.ID, 10)); code != http.StatusNotFound {
+			t.Fatalf("revoke twice: expected 404, got %d", code)
+		}
+	})
+}
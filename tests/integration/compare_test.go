@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	env := setup(t)
+
+	for _, r := range []struct {
+		version, from, platform string
+		jiras                   []string
+	}{
+		{"android-5.0", "", "android", []string{"X-1", "X-2"}},
+		{"android-5.2", "android-5.0", "android", []string{"X-3", "X-4"}},
+		{"ios-5.0", "", "ios", []string{"X-1"}},
+		{"ios-5.2", "ios-5.0", "ios", []string{"X-3", "X-5"}},
+	} {
+		changes := make([]map[string]string, len(r.jiras))
+		for i, id := range r.jiras {
+			changes[i] = map[string]string{"id": id, "title": "title " + id}
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": r.version, "from_ver": r.from, "platform": r.platform},
+			"changes": changes,
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	code, body := env.get(t, "/api/compare?left=android:android-5.2&right=ios:ios-5.2")
+	if code != 200 {
+		t.Fatalf("expected 200, got %d: %s", code, body)
+	}
+	type comparison struct {
+		OnlyLeft  []map[string]string `json:"only_left"`
+		OnlyRight []map[string]string `json:"only_right"`
+		Common    []map[string]string `json:"common"`
+	}
+	got := decode[comparison](t, body)
+
+	ids := func(jiras []map[string]string) []string {
+		out := make([]string, len(jiras))
+		for i, j := range jiras {
+			out[i] = j["id"]
+		}
+		return out
+	}
+	check := func(name string, got []map[string]string, want ...string) {
+		t.Helper()
+		g := ids(got)
+		if len(g) != len(want) {
+			t.Fatalf("%s: got %v, want %v", name, g, want)
+		}
+		for i := range want {
+			if g[i] != want[i] {
+				t.Fatalf("%s: got %v, want %v", name, g, want)
+			}
+		}
+	}
+	check("only_left", got.OnlyLeft, "X-2", "X-4")
+	check("only_right", got.OnlyRight, "X-5")
+	check("common", got.Common, "X-1", "X-3")
+	if got.Common[0]["title"] != "title X-1" {
+		t.Fatalf("expected jira metadata, got %v", got.Common[0])
+	}
+
+	if code, _ := env.get(t, "/api/compare?left=ios:android-5.2&right=ios:ios-5.2"); code != 404 {
+		t.Fatalf("platform mismatch: expected 404, got %d", code)
+	}
+	if code, _ := env.get(t, "/api/compare?left=android-5.2&right=ios:ios-5.2"); code != 400 {
+		t.Fatalf("malformed ref: expected 400, got %d", code)
+	}
+}
@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestReleaseContents(t *testing.T) {
+	env := setup(t)
+
+	for _, r := range []struct {
+		version, from string
+		jiras         []map[string]string
+	}{
+		{"1.0.0", "", []map[string]string{
+			{"id": "1", "domain": "auth", "impact": "high"},
+			{"id": "2", "domain": "ui", "impact": "low"},
+		}},
+		{"1.1.0", "1.0.0", []map[string]string{
+			{"id": "10", "domain": "auth", "impact": "low"},
+		}},
+		{"1.2.0", "1.1.0", []map[string]string{
+			{"id": "3", "domain": "auth", "impact": "high"},
+		}},
+		{"1.1.1", "1.1.0", []map[string]string{
+			{"id": "4", "domain": "auth", "impact": "high"},
+		}},
+	} {
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": r.version, "from_ver": r.from, "platform": "ios"},
+			"changes": r.jiras,
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	type page struct {
+		Jiras []map[string]string `json:"jiras"`
+		Total int                 `json:"total"`
+	}
+	ids := func(p page) []string {
+		out := make([]string, len(p.Jiras))
+		for i, j := range p.Jiras {
+			out[i] = j["id"]
+		}
+		return out
+	}
+	check := func(path string, total int, want ...string) {
+		t.Helper()
+		code, body := env.get(t, path)
+		if code != 200 {
+			t.Fatalf("%s: expected 200, got %d: %s", path, code, body)
+		}
+		p := decode[page](t, body)
+		got := ids(p)
+		if p.Total != total || len(got) != len(want) {
+			t.Fatalf("%s: got %v (total %d), want %v (total %d)", path, got, p.Total, want, total)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: got %v, want %v", path, got, want)
+			}
+		}
+	}
+
+	check("/api/releases/1.2.0/contents", 4, "1", "2", "3", "10")
+	check("/api/releases/1.1.1/contents", 4, "1", "2", "4", "10")
+	check("/api/releases/1.2.0/contents?domain=auth&impact=high", 2, "1", "3")
+	check("/api/releases/1.2.0/contents?limit=2&offset=1", 4, "2", "3")
+	check("/api/releases/1.2.0/contents?offset=10", 4)
+
+	if code, _ := env.get(t, "/api/releases/9.9.9/contents"); code != 404 {
+		t.Fatalf("missing release: expected 404, got %d", code)
+	}
+}
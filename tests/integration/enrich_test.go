@@ -0,0 +1,203 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jiraiya/internal/jiraapi"
+	"jiraiya/internal/service"
+)
+
+func TestJiraEnrichment(t *testing.T) {
+	issues := map[string]string{
+		"EN-1": `{"fields":{"summary":"Crash on launch","components":[{"name":"Player"}],"priority":{"name":"High"},"customfield_7":"Fixed a crash on launch"}}`,
+		"EN-2": `{"fields":{"summary":"Remote title","components":[{"name":"Search"}],"priority":{"name":"Low"}}}`,
+	}
+	var hits atomic.Int32
+	jira := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		body, ok := issues[strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(jira.Close)
+
+	env := setupWithConfig(t, service.Config{Jira: jiraapi.Config{
+		BaseURL: jira.URL,
+		Fields:  jiraapi.FieldMap{Title: "summary", Domain: "components", Impact: "priority", Relnotes: "customfield_7"},
+	}})
+
+	code, body := env.put(t, "/api/releases", map[string]any{
+		"release": map[string]string{"version": "1.0", "platform": "android"},
+		"changes": []map[string]string{
+			{"id": "EN-1"},
+			{"id": "EN-2", "title": "Kept title"},
+			{"id": "EN-404"},
+		},
+	})
+	if code != 200 {
+		t.Fatalf("submit: expected 200, got %d: %s", code, body)
+	}
+
+	// Enrichment runs in the background after the submission.
+	var en1 map[string]any
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		_, body := env.get(t, "/api/jiras/EN-1")
+		en1 = decode[map[string]any](t, body)
+		if en1["title"] != "" || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if en1["title"] != "Crash on launch" || en1["domain"] != "Player" || en1["impact"] != "High" || en1["relnotes"] != "Fixed a crash on launch" {
+		t.Fatalf("EN-1 not enriched: %v", en1)
+	}
+
+	// Wait for the background pass to finish before checking the cache.
+	ctx := context.Background()
+	for {
+		n, err := env.svc.EnrichJiras(ctx)
+		if err != nil {
+			t.Fatalf("enrich: %v", err)
+		}
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("enrichment did not settle")
+		}
+	}
+
+	_, body = env.get(t, "/api/jiras/EN-2")
+	en2 := decode[map[string]any](t, body)
+	if en2["title"] != "Kept title" {
+		t.Fatalf("submitted title was overwritten: %v", en2)
+	}
+	if en2["domain"] != "Search" || en2["impact"] != "Low" {
+		t.Fatalf("EN-2 empty fields not filled: %v", en2)
+	}
+
+	_, body = env.get(t, "/api/filters?platform=android")
+	filters := decode[service.Filters](t, body)
+	if strings.Join(filters.Domains, ",") != "Player,Search" {
+		t.Fatalf("expected enriched domains in filters, got %v", filters.Domains)
+	}
+
+	// Found and missing issues are both cached, so another pass asks Jira
+	// nothing.
+	before := hits.Load()
+	if n, err := env.svc.EnrichJiras(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing to enrich, got %d, %v", n, err)
+	}
+	if hits.Load() != before {
+		t.Fatalf("cached jiras were looked up again")
+	}
+
+	t.Run("bare resubmission keeps enriched metadata", func(t *testing.T) {
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": "1.0", "platform": "android"},
+			"changes": []map[string]string{{"id": "EN-1"}, {"id": "EN-2"}, {"id": "EN-404"}},
+		})
+		if code != 200 {
+			t.Fatalf("resubmit: expected 200, got %d: %s", code, body)
+		}
+		if got := decode[map[string]any](t, body); got["warnings"] != nil {
+			t.Fatalf("expected no warnings for bare ids, got %v", got["warnings"])
+		}
+
+		_, body = env.get(t, "/api/jiras/EN-1")
+		if en1 := decode[map[string]any](t, body); en1["title"] != "Crash on launch" || en1["domain"] != "Player" {
+			t.Fatalf("EN-1 lost its enriched metadata: %v", en1)
+		}
+		_, body = env.get(t, "/api/jiras/EN-2")
+		if en2 := decode[map[string]any](t, body); en2["title"] != "Kept title" {
+			t.Fatalf("EN-2 lost its submitted title: %v", en2)
+		}
+	})
+
+	t.Run("as-of diffs see enriched metadata", func(t *testing.T) {
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": "1.1", "from_ver": "1.0", "platform": "android"},
+			"changes": []map[string]string{{"id": "EN-1"}},
+		})
+		if code != 200 {
+			t.Fatalf("submit 1.1: expected 200, got %d: %s", code, body)
+		}
+		code, body = env.get(t, "/api/jiras?from=1.0&to=1.1&as_of=to")
+		if code != 200 {
+			t.Fatalf("as-of diff: expected 200, got %d: %s", code, body)
+		}
+		got := decode[[]map[string]any](t, body)
+		if len(got) != 1 || got[0]["title"] != "Crash on launch" || got[0]["domain"] != "Player" {
+			t.Fatalf("expected enriched EN-1 as of 1.1, got %v", got)
+		}
+	})
+}
+
+func TestJiraEnrichmentFailure(t *testing.T) {
+	var brokenHits atomic.Int32
+	jira := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/") {
+		case "EF-1":
+			brokenHits.Add(1)
+			http.Error(w, "boom", http.StatusInternalServerError)
+		case "EF-2":
+			w.Write([]byte(`{"fields":{"summary":"After the broken one"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(jira.Close)
+
+	env := setupWithConfig(t, service.Config{Jira: jiraapi.Config{
+		BaseURL: jira.URL,
+		Fields:  jiraapi.FieldMap{Title: "summary"},
+	}})
+
+	code, body := env.put(t, "/api/releases", map[string]any{
+		"release": map[string]string{"version": "1.0", "platform": "android"},
+		"changes": []map[string]string{{"id": "EF-1"}, {"id": "EF-2"}},
+	})
+	if code != 200 {
+		t.Fatalf("submit: expected 200, got %d: %s", code, body)
+	}
+
+	// EF-1 sorts first and fails; EF-2 must still be enriched.
+	ctx := context.Background()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if _, err := env.svc.EnrichJiras(ctx); err != nil {
+			t.Fatalf("enrich: %v", err)
+		}
+		_, body := env.get(t, "/api/jiras/EF-2")
+		if decode[map[string]any](t, body)["title"] == "After the broken one" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("EF-2 was not enriched past the failing EF-1: %s", body)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// The failure is recorded, so the next pass backs off instead of
+	// asking Jira again straight away.
+	before := brokenHits.Load()
+	if before == 0 {
+		t.Fatal("EF-1 was never looked up")
+	}
+	if n, err := env.svc.EnrichJiras(ctx); err != nil || n != 0 {
+		t.Fatalf("expected nothing to enrich, got %d, %v", n, err)
+	}
+	if brokenHits.Load() != before {
+		t.Fatalf("failed jira was looked up again before its backoff")
+	}
+}
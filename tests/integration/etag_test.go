@@ -0,0 +1,64 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestOptimisticConcurrency(t *testing.T) {
+	env := setup(t)
+
+	release := func(jiraID string) map[string]any {
+		return map[string]any{
+			"release": map[string]string{"version": "5.0.0", "from_ver": "", "platform": "ios"},
+			"changes": []map[string]string{{"id": jiraID}},
+		}
+	}
+
+	t.Run("If-Match on missing release fails", func(t *testing.T) {
+		resp := env.do(t, http.MethodPut, "/api/releases", release("E-1"), http.Header{"If-Match": {`"nope"`}})
+		if resp.StatusCode != http.StatusPreconditionFailed {
+			t.Fatalf("expected 412, got %d", resp.StatusCode)
+		}
+	})
+
+	resp := env.do(t, http.MethodPut, "/api/releases", release("E-1"), nil)
+	if resp.StatusCode != 200 {
+		t.Fatalf("submit: expected 200, got %d", resp.StatusCode)
+	}
+	submitTag := resp.Header.Get("ETag")
+
+	resp = env.do(t, http.MethodGet, "/api/releases?version=5.0.0", nil, nil)
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header on GET")
+	}
+	if etag != submitTag {
+		t.Fatalf("PUT ETag %s does not match GET ETag %s", submitTag, etag)
+	}
+
+	resp = env.do(t, http.MethodPut, "/api/releases", release("E-2"), http.Header{"If-Match": {etag}})
+	if resp.StatusCode != 200 {
+		t.Fatalf("matching If-Match: expected 200, got %d", resp.StatusCode)
+	}
+
+	t.Run("stale If-Match on PUT fails", func(t *testing.T) {
+		resp := env.do(t, http.MethodPut, "/api/releases", release("E-3"), http.Header{"If-Match": {etag}})
+		if resp.StatusCode != http.StatusPreconditionFailed {
+			t.Fatalf("expected 412, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("stale If-Match on DELETE fails", func(t *testing.T) {
+		resp := env.do(t, http.MethodDelete, "/api/releases/5.0.0", nil, http.Header{"If-Match": {etag}})
+		if resp.StatusCode != http.StatusPreconditionFailed {
+			t.Fatalf("expected 412, got %d", resp.StatusCode)
+		}
+	})
+
+	resp = env.do(t, http.MethodGet, "/api/releases?version=5.0.0", nil, nil)
+	resp = env.do(t, http.MethodDelete, "/api/releases/5.0.0", nil, http.Header{"If-Match": {resp.Header.Get("ETag")}})
+	if resp.StatusCode != 200 {
+		t.Fatalf("delete with current ETag: expected 200, got %d", resp.StatusCode)
+	}
+}
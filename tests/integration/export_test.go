@@ -0,0 +1,95 @@
+package integration
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	env := setup(t)
+
+	for i := 0; i < 3; i++ {
+		from := ""
+		if i > 0 {
+			from = fmt.Sprintf("1.%d.0", i-1)
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": fmt.Sprintf("1.%d.0", i), "from_ver": from, "platform": "ios"},
+			"changes": []map[string]string{{"id": fmt.Sprintf("C-%d", i), "title": "Fix, with \"quotes\""}},
+		})
+		if code != 200 {
+			t.Fatalf("submit: expected 200, got %d: %s", code, body)
+		}
+	}
+
+	readCSV := func(t *testing.T, resp *http.Response) [][]string {
+		t.Helper()
+		if resp.StatusCode != 200 {
+			t.Fatalf("expected 200, got %d", resp.StatusCode)
+		}
+		if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+			t.Fatalf("expected text/csv, got %q", ct)
+		}
+		records, err := csv.NewReader(resp.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("read csv: %v", err)
+		}
+		return records
+	}
+
+	t.Run("jiras csv via accept", func(t *testing.T) {
+		resp := env.do(t, http.MethodGet, "/api/jiras?from=1.0.0&to=1.2.0", nil, http.Header{"Accept": {"text/csv"}})
+		records := readCSV(t, resp)
+		if strings.Join(records[0], ",") != "id,title,impact,domain,relnotes,release" {
+			t.Fatalf("unexpected header %v", records[0])
+		}
+		if len(records) != 3 || records[1][1] != "Fix, with \"quotes\"" {
+			t.Fatalf("unexpected records %v", records)
+		}
+	})
+
+	t.Run("releases csv via format, paged internally", func(t *testing.T) {
+		resp := env.do(t, http.MethodGet, "/api/releases?platform=ios&format=csv&limit=1", nil, nil)
+		records := readCSV(t, resp)
+		if len(records) != 4 || records[0][0] != "version" || records[3][0] != "1.2.0" {
+			t.Fatalf("unexpected records %v", records)
+		}
+	})
+
+	t.Run("versions ndjson", func(t *testing.T) {
+		resp := env.do(t, http.MethodGet, "/api/versions?platform=ios", nil, http.Header{"Accept": {"application/x-ndjson"}})
+		if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+			t.Fatalf("expected application/x-ndjson, got %q", ct)
+		}
+		var versions []string
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			var v map[string]string
+			if err := json.Unmarshal(sc.Bytes(), &v); err != nil {
+				t.Fatalf("decode line %q: %v", sc.Text(), err)
+			}
+			versions = append(versions, v["version"])
+		}
+		if len(versions) != 3 {
+			t.Fatalf("expected 3 lines, got %v", versions)
+		}
+	})
+
+	t.Run("empty csv still has header", func(t *testing.T) {
+		resp := env.do(t, http.MethodGet, "/api/versions?platform=android&format=csv", nil, nil)
+		if records := readCSV(t, resp); len(records) != 1 {
+			t.Fatalf("expected header only, got %v", records)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		if code, _ := env.get(t, "/api/versions?platform=ios&format=xml"); code != http.StatusBadRequest {
+			t.Fatalf("expected 400, got %d", code)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package integration
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"jiraiya/internal/service"
+)
+
+func TestReleaseFeeds(t *testing.T) {
+	env := setup(t)
+
+	for _, r := range []struct {
+		version, from string
+		jiras         []string
+	}{
+		{"6.0", "", []string{"F-1"}},
+		{"6.1", "6.0", []string{"F-2"}},
+		{"7.0", "6.0", []string{"F-3"}},
+		{"7.1", "7.0", []string{"F-4", "F-5"}},
+	} {
+		changes := make([]map[string]string, len(r.jiras))
+		for i, id := range r.jiras {
+			changes[i] = map[string]string{"id": id, "title": "title <" + id + ">"}
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": r.version, "from_ver": r.from, "platform": "android"},
+			"changes": changes,
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	type atomEntry struct {
+		ID      string `xml:"id"`
+		Title   string `xml:"title"`
+		Updated string `xml:"updated"`
+		Content struct {
+			Type string `xml:"type,attr"`
+			Body string `xml:",chardata"`
+		} `xml:"content"`
+	}
+	type atomFeed struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string      `xml:"id"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	resp := env.do(t, http.MethodGet, "/api/feeds/android.atom", nil, nil)
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/atom+xml") {
+		t.Fatalf("expected atom content type, got %q", ct)
+	}
+
+	code, body := env.get(t, "/api/feeds/android.atom")
+	if code != 200 {
+		t.Fatalf("expected 200, got %d: %s", code, body)
+	}
+	var feed atomFeed
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("unmarshal atom: %v\n%s", err, body)
+	}
+	if len(feed.Entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(feed.Entries))
+	}
+	// The last submission is the newest entry.
+	first := feed.Entries[0]
+	if first.ID != "urn:jiraiya:release:android:7.1" {
+		t.Fatalf("unexpected entry id %q", first.ID)
+	}
+	if first.Content.Type != "html" {
+		t.Fatalf("expected html content, got %q", first.Content.Type)
+	}
+	if !strings.Contains(first.Content.Body, "F-4") || strings.Contains(first.Content.Body, "F-3") {
+		t.Fatalf("expected only the jiras added over 7.0, got %s", first.Content.Body)
+	}
+	if !strings.Contains(first.Content.Body, "title &lt;F-4&gt;") {
+		t.Fatalf("expected escaped jira title, got %s", first.Content.Body)
+	}
+
+	// Entry ids stay the same across fetches.
+	_, again := env.get(t, "/api/feeds/android.atom")
+	var feed2 atomFeed
+	if err := xml.Unmarshal(again, &feed2); err != nil {
+		t.Fatalf("unmarshal atom: %v", err)
+	}
+	for i := range feed.Entries {
+		if feed.Entries[i].ID != feed2.Entries[i].ID || feed.Entries[i].Updated != feed2.Entries[i].Updated {
+			t.Fatalf("entry %d changed between fetches: %+v vs %+v", i, feed.Entries[i], feed2.Entries[i])
+		}
+	}
+
+	// The branch filter keeps 7.0 and its descendants.
+	code, body = env.get(t, "/api/feeds/android.atom?branch=7.0")
+	if code != 200 {
+		t.Fatalf("branch: expected 200, got %d: %s", code, body)
+	}
+	var branch atomFeed
+	if err := xml.Unmarshal(body, &branch); err != nil {
+		t.Fatalf("unmarshal atom: %v", err)
+	}
+	if len(branch.Entries) != 2 {
+		t.Fatalf("branch: expected 2 entries, got %d", len(branch.Entries))
+	}
+	for _, e := range branch.Entries {
+		if e.ID != "urn:jiraiya:release:android:7.0" && e.ID != "urn:jiraiya:release:android:7.1" {
+			t.Fatalf("branch: unexpected entry %q", e.ID)
+		}
+	}
+
+	type rssFeed struct {
+		Channel struct {
+			Items []struct {
+				GUID        string `xml:"guid"`
+				Description string `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	code, body = env.get(t, "/api/feeds/android.rss?limit=2")
+	if code != 200 {
+		t.Fatalf("rss: expected 200, got %d: %s", code, body)
+	}
+	var rss rssFeed
+	if err := xml.Unmarshal(body, &rss); err != nil {
+		t.Fatalf("unmarshal rss: %v\n%s", err, body)
+	}
+	if len(rss.Channel.Items) != 2 {
+		t.Fatalf("rss: expected 2 items, got %d", len(rss.Channel.Items))
+	}
+	if rss.Channel.Items[0].GUID != first.ID {
+		t.Fatalf("rss guid %q does not match atom id %q", rss.Channel.Items[0].GUID, first.ID)
+	}
+
+	if code, _ := env.get(t, "/api/feeds/android.json"); code != 404 {
+		t.Fatalf("unknown format: expected 404, got %d", code)
+	}
+	if code, _ := env.get(t, "/api/feeds/android.atom?branch=9.9"); code != 404 {
+		t.Fatalf("unknown branch: expected 404, got %d", code)
+	}
+	if code, _ := env.get(t, "/api/feeds/android.atom?limit=x"); code != 400 {
+		t.Fatalf("bad limit: expected 400, got %d", code)
+	}
+}
+
+func TestFeedTokenParam(t *testing.T) {
+	env := setup(t)
+	for _, platform := range []string{"android", "ios"} {
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": platform + "-1.0", "platform": platform},
+			"changes": []map[string]string{{"id": "FT-" + platform}},
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", platform, code, body)
+		}
+	}
+	tok, err := env.svc.CreateToken(context.Background(), service.TokenInput{
+		Name:   "android-feed",
+		Grants: []service.Grant{{Platform: "android", Role: service.RoleAdmin}},
+	})
+	if err != nil {
+		t.Fatalf("create token: %v", err)
+	}
+
+	// Feed readers send no Authorization header
+	fetch := func(path string) int {
+		t.Helper()
+		resp, err := http.Get(env.srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	q := "?token=" + url.QueryEscape(tok.Token)
+	if code := fetch("/api/feeds/android.atom" + q); code != http.StatusOK {
+		t.Fatalf("feed with token param: expected 200, got %d", code)
+	}
+	if code := fetch("/api/feeds/android.rss"); code != http.StatusUnauthorized {
+		t.Fatalf("feed without a token: expected 401, got %d", code)
+	}
+	if code := fetch("/api/feeds/ios.atom" + q); code != http.StatusForbidden {
+		t.Fatalf("feed of an ungranted platform: expected 403, got %d", code)
+	}
+	if code := fetch("/api/versions?platform=android&token=" + url.QueryEscape(tok.Token)); code != http.StatusUnauthorized {
+		t.Fatalf("token param outside feeds: expected 401, got %d", code)
+	}
+}
@@ -0,0 +1,253 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"jiraiya/internal/service"
+)
+
+func TestPlatformGrants(t *testing.T) {
+	env := setup(t)
+
+	for _, rel := range []struct{ version, from, platform string }{
+		{"ios-1.0", "", "ios"},
+		{"ios-1.1", "ios-1.0", "ios"},
+		{"desktop-1.0", "", "desktop"},
+		{"desktop-1.1", "desktop-1.0", "desktop"},
+	} {
+		sub := map[string]any{
+			"release": map[string]string{"version": rel.version, "from_ver": rel.from, "platform": rel.platform},
+			"changes": []map[string]string{{"id": "GRANT-" + rel.version}},
+		}
+		if code, body := env.put(t, "/api/releases", sub); code != http.StatusOK {
+			t.Fatalf("seed %s: expected 200, got %d: %s", rel.version, code, body)
+		}
+	}
+
+	mobile := env.withGrants(t, "mobile", service.Grant{Platform: "ios", Role: service.RoleAdmin})
+	desktop := env.withGrants(t, "desktop-ci", service.Grant{Platform: "desktop", Role: service.RoleSubmitter})
+	mixed := env.withGrants(t, "mixed",
+		service.Grant{Platform: service.AllPlatforms, Role: service.RoleReader},
+		service.Grant{Platform: "desktop", Role: service.RoleSubmitter},
+	)
+
+	t.Run("submit", func(t *testing.T) {
+		sub := func(version, platform string) map[string]any {
+			return map[string]any{
+				"release": map[string]string{"version": version, "platform": platform},
+				"changes": []map[string]string{{"id": "GRANT-NEW"}},
+			}
+		}
+		if code, body := desktop.put(t, "/api/releases", sub("ios-2.0", "ios")); code != http.StatusForbidden {
+			t.Fatalf("desktop submitter on ios: expected 403, got %d: %s", code, body)
+		}
+		if code, body := desktop.put(t, "/api/releases", sub("desktop-2.0", "desktop")); code != http.StatusOK {
+			t.Fatalf("desktop submitter on desktop: expected 200, got %d: %s", code, body)
+		}
+		// Moving a release needs a grant on the platform it leaves too
+		if code, body := desktop.put(t, "/api/releases", sub("ios-1.1", "desktop")); code != http.StatusForbidden {
+			t.Fatalf("desktop submitter moving ios release: expected 403, got %d: %s", code, body)
+		}
+		if code, body := mixed.put(t, "/api/releases", sub("ios-2.0", "ios")); code != http.StatusForbidden {
+			t.Fatalf("wildcard reader on ios: expected 403, got %d: %s", code, body)
+		}
+	})
+
+	t.Run("delete learns the platform from the release", func(t *testing.T) {
+		if code, body := mobile.delete(t, "/api/releases/desktop-1.1"); code != http.StatusForbidden {
+			t.Fatalf("ios admin deleting desktop release: expected 403, got %d: %s", code, body)
+		}
+		if code, _ := env.get(t, "/api/releases?version=desktop-1.1"); code != http.StatusOK {
+			t.Fatalf("desktop-1.1 should survive a forbidden delete, got %d", code)
+		}
+		if code, body := mobile.delete(t, "/api/releases/ios-1.1"); code != http.StatusOK {
+			t.Fatalf("ios admin deleting ios release: expected 200, got %d: %s", code, body)
+		}
+	})
+
+	t.Run("tree and diff", func(t *testing.T) {
+		if code, _ := mobile.get(t, "/api/admin/tree?platform=desktop"); code != http.StatusForbidden {
+			t.Fatalf("ios admin desktop tree: expected 403, got %d", code)
+		}
+		if code, body := mobile.get(t, "/api/admin/tree?platform=ios"); code != http.StatusOK {
+			t.Fatalf("ios admin ios tree: expected 200, got %d: %s", code, body)
+		}
+		if code, _ := mobile.get(t, "/api/jiras?from=desktop-1.0&to=desktop-1.1"); code != http.StatusForbidden {
+			t.Fatalf("ios admin desktop diff: expected 403, got %d", code)
+		}
+		if code, body := mixed.get(t, "/api/jiras?from=desktop-1.0&to=desktop-1.1"); code != http.StatusOK {
+			t.Fatalf("wildcard reader desktop diff: expected 200, got %d: %s", code, body)
+		}
+		if code, _ := desktop.get(t, "/api/compare?left=desktop:desktop-1.0&right=ios:ios-1.0"); code != http.StatusForbidden {
+			t.Fatalf("desktop submitter comparing with ios: expected 403, got %d", code)
+		}
+	})
+
+	t.Run("reads", func(t *testing.T) {
+		for _, path := range []string{
+			"/api/releases?platform=desktop",
+			"/api/releases?version=desktop-1.0",
+			"/api/releases/desktop-1.0/history",
+			"/api/releases/desktop-1.0/contents",
+			"/api/versions?platform=desktop",
+			"/api/filters?platform=desktop",
+			"/api/feeds/desktop.atom",
+		} {
+			if code, body := mobile.get(t, path); code != http.StatusForbidden {
+				t.Fatalf("ios admin GET %s: expected 403, got %d: %s", path, code, body)
+			}
+			if code, body := mixed.get(t, path); code != http.StatusOK {
+				t.Fatalf("wildcard reader GET %s: expected 200, got %d: %s", path, code, body)
+			}
+		}
+		if code, body := mobile.get(t, "/api/versions?platform=ios"); code != http.StatusOK {
+			t.Fatalf("ios admin ios versions: expected 200, got %d: %s", code, body)
+		}
+
+		// Jira metadata is shared, but only readable releases are listed
+		code, body := mobile.get(t, "/api/jiras/GRANT-desktop-1.0")
+		if code != http.StatusOK {
+			t.Fatalf("ios admin desktop jira: expected 200, got %d: %s", code, body)
+		}
+		if got := decode[service.JiraDetail](t, body).Releases; len(got) != 0 {
+			t.Fatalf("ios admin should not see desktop releases, got %v", got)
+		}
+	})
+
+	t.Run("graphql", func(t *testing.T) {
+		resp := mobile.do(t, http.MethodPost, "/api/graphql", map[string]string{"query": `{
+			platforms { name }
+			desktop: platform(name: "desktop") { name }
+			release(version: "desktop-1.0") { version }
+			jira(id: "GRANT-desktop-1.0") { id releases { version } }
+		}`}, http.Header{"Content-Type": {"application/json"}})
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		type named struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		}
+		out := decode[struct {
+			Data struct {
+				Platforms []named `json:"platforms"`
+				Desktop   *named  `json:"desktop"`
+				Release   *named  `json:"release"`
+				Jira      *struct {
+					Releases []named `json:"releases"`
+				} `json:"jira"`
+			} `json:"data"`
+		}](t, body)
+		d := out.Data
+		if len(d.Platforms) != 1 || d.Platforms[0].Name != "ios" {
+			t.Fatalf("expected only ios among platforms, got %s", body)
+		}
+		if d.Desktop != nil || d.Release != nil {
+			t.Fatalf("desktop platform and release should be hidden, got %s", body)
+		}
+		if d.Jira == nil || len(d.Jira.Releases) != 0 {
+			t.Fatalf("desktop jira should list no releases, got %s", body)
+		}
+	})
+
+	t.Run("release events", func(t *testing.T) {
+		id := &service.Identity{Name: "mobile", Role: service.RoleAdmin, Grants: map[string]string{"ios": service.RoleAdmin}}
+		ctx := service.WithIdentity(context.Background(), id)
+		events, err := env.svc.ListReleaseEvents(ctx, service.EventQuery{})
+		if err != nil {
+			t.Fatalf("list release events: %v", err)
+		}
+		if len(events) == 0 {
+			t.Fatal("expected ios events")
+		}
+		for _, e := range events {
+			if e.Release.Platform != "ios" {
+				t.Fatalf("ios admin streamed a %s event", e.Release.Platform)
+			}
+		}
+		if _, err := env.svc.ListReleaseEvents(ctx, service.EventQuery{Platform: "desktop"}); !errors.Is(err, service.ErrForbidden) {
+			t.Fatalf("ios admin desktop events: expected ErrForbidden, got %v", err)
+		}
+	})
+
+	t.Run("webhooks", func(t *testing.T) {
+		hooks := map[string]int64{}
+		for _, platform := range []string{"ios", "desktop"} {
+			resp := env.do(t, http.MethodPost, "/api/webhooks", map[string]any{
+				"platform": platform, "url": "http://127.0.0.1:1/" + platform, "secret": "s",
+			}, nil)
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != http.StatusCreated {
+				t.Fatalf("create %s webhook: expected 201, got %d: %s", platform, resp.StatusCode, body)
+			}
+			hooks[platform] = decode[service.Webhook](t, body).ID
+		}
+		sub := map[string]any{
+			"release": map[string]string{"version": "desktop-3.0", "from_ver": "desktop-1.1", "platform": "desktop"},
+			"changes": []map[string]string{{"id": "GRANT-HOOK"}},
+		}
+		if code, body := env.put(t, "/api/releases", sub); code != http.StatusOK {
+			t.Fatalf("submit desktop-3.0: expected 200, got %d: %s", code, body)
+		}
+
+		desktopHook := fmt.Sprintf("/api/webhooks/%d", hooks["desktop"])
+		for _, path := range []string{desktopHook, desktopHook + "/deliveries", "/api/webhooks?platform=desktop", "/api/webhooks/dead-letters?platform=desktop"} {
+			if code, body := mobile.get(t, path); code != http.StatusForbidden {
+				t.Fatalf("ios admin GET %s: expected 403, got %d: %s", path, code, body)
+			}
+		}
+
+		code, body := mobile.get(t, "/api/webhooks")
+		if code != http.StatusOK {
+			t.Fatalf("ios admin webhooks: expected 200, got %d: %s", code, body)
+		}
+		if got := decode[[]service.Webhook](t, body); len(got) != 1 || got[0].ID != hooks["ios"] {
+			t.Fatalf("ios admin should only see the ios webhook, got %s", body)
+		}
+
+		_, body = env.get(t, desktopHook+"/deliveries")
+		deliveries := decode[[]service.WebhookDelivery](t, body)
+		if len(deliveries) == 0 {
+			t.Fatalf("expected a desktop delivery, got %s", body)
+		}
+		retry := fmt.Sprintf("/api/webhooks/deliveries/%d/retry", deliveries[0].ID)
+		if resp := mobile.do(t, http.MethodPost, retry, nil, nil); resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("ios admin retrying desktop delivery: expected 403, got %d", resp.StatusCode)
+		}
+
+		if code, body := mobile.delete(t, desktopHook); code != http.StatusForbidden {
+			t.Fatalf("ios admin deleting desktop webhook: expected 403, got %d: %s", code, body)
+		}
+		if code, _ := env.get(t, desktopHook); code != http.StatusOK {
+			t.Fatalf("desktop webhook should survive a forbidden delete, got %d", code)
+		}
+	})
+
+	t.Run("tokens need a wildcard admin", func(t *testing.T) {
+		resp := mobile.do(t, http.MethodPost, "/api/admin/tokens", map[string]any{
+			"name":   "escalated",
+			"grants": []map[string]string{{"platform": "*", "role": "admin"}},
+		}, nil)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Fatalf("ios admin creating token: expected 403, got %d", resp.StatusCode)
+		}
+
+		_, body := env.get(t, "/api/admin/tokens")
+		for _, tok := range decode[[]service.APIToken](t, body) {
+			if tok.Name != "mobile" {
+				continue
+			}
+			if tok.Role != service.RoleAdmin || len(tok.Grants) != 1 || tok.Grants[0] != (service.Grant{Platform: "ios", Role: service.RoleAdmin}) {
+				t.Fatalf("unexpected mobile token %+v", tok)
+			}
+			return
+		}
+		t.Fatalf("mobile token not listed: %s", body)
+	})
+}
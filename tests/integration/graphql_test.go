@@ -0,0 +1,115 @@
+package integration
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestGraphQL(t *testing.T) {
+	env := setup(t)
+
+	for _, r := range []struct {
+		version, from string
+		jiras         []string
+	}{
+		{"7.0", "", []string{"Q-1"}},
+		{"7.1", "7.0", []string{"Q-2", "Q-3"}},
+		{"7.2", "7.0", []string{"Q-3"}},
+		{"7.1.1", "7.1", []string{"Q-4"}},
+	} {
+		changes := make([]map[string]string, len(r.jiras))
+		for i, id := range r.jiras {
+			changes[i] = map[string]string{"id": id, "title": "title " + id}
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": r.version, "from_ver": r.from, "platform": "ios"},
+			"changes": changes,
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	query := func(t *testing.T, q string) (json.RawMessage, []map[string]any) {
+		t.Helper()
+		resp := env.do(t, http.MethodPost, "/api/graphql", map[string]string{"query": q}, http.Header{"Content-Type": {"application/json"}})
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != 200 {
+			t.Fatalf("expected 200, got %d: %s", resp.StatusCode, body)
+		}
+		out := decode[struct {
+			Data   json.RawMessage  `json:"data"`
+			Errors []map[string]any `json:"errors"`
+		}](t, body)
+		return out.Data, out.Errors
+	}
+
+	t.Run("walks the tree", func(t *testing.T) {
+		data, errs := query(t, `{
+			platform(name: "ios") {
+				root {
+					version
+					children {
+						version
+						jiras { id }
+						children { version ancestors { version } }
+					}
+				}
+			}
+		}`)
+		want := `{"platform":{"root":{"version":"7.0","children":[` +
+			`{"version":"7.1","jiras":[{"id":"Q-2"},{"id":"Q-3"}],"children":[{"version":"7.1.1","ancestors":[{"version":"7.1"},{"version":"7.0"}]}]},` +
+			`{"version":"7.2","jiras":[{"id":"Q-3"}],"children":[]}]}}}`
+		if len(errs) > 0 || string(data) != want {
+			t.Fatalf("expected %s, got %s %v", want, data, errs)
+		}
+	})
+
+	t.Run("jira releases and diffs", func(t *testing.T) {
+		data, errs := query(t, `{
+			jira(id: "Q-3") { releases { version parent { version } } }
+			release(version: "7.1.1") { jirasSince(from: "7.2") { id introducedIn { version } } }
+		}`)
+		type payload struct {
+			Jira struct {
+				Releases []struct {
+					Version string
+					Parent  struct{ Version string }
+				}
+			}
+			Release struct {
+				JirasSince []struct {
+					ID           string
+					IntroducedIn struct{ Version string }
+				}
+			}
+		}
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors %v", errs)
+		}
+		got := decode[payload](t, data)
+		if r := got.Jira.Releases; len(r) != 2 || r[0].Version != "7.1" || r[1].Version != "7.2" || r[0].Parent.Version != "7.0" {
+			t.Fatalf("unexpected jira releases %+v", r)
+		}
+		since := map[string]string{}
+		for _, j := range got.Release.JirasSince {
+			since[j.ID] = j.IntroducedIn.Version
+		}
+		if len(since) != 2 || since["Q-2"] != "7.1" || since["Q-4"] != "7.1.1" {
+			t.Fatalf("unexpected diff %+v", got.Release.JirasSince)
+		}
+	})
+
+	t.Run("rejects bad requests", func(t *testing.T) {
+		resp := env.do(t, http.MethodPost, "/api/graphql", map[string]string{"operation": "{ platforms { name } }"}, nil)
+		if resp.StatusCode != 400 {
+			t.Fatalf("expected 400 without a query, got %d", resp.StatusCode)
+		}
+		_, errs := query(t, `{ platforms { nope } }`)
+		if len(errs) != 1 {
+			t.Fatalf("expected a query error, got %v", errs)
+		}
+	})
+}
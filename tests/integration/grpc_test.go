@@ -0,0 +1,123 @@
+package integration
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"jiraiya/internal/grpcapi"
+	"jiraiya/internal/service"
+	"jiraiya/pkg/pb/jiraiyav1"
+)
+
+// grpcClient serves the gRPC API over env's service on a local port.
+func grpcClient(t *testing.T, env *testEnv) jiraiyav1.ReleaseServiceClient {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpcapi.New(env.svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	go srv.Serve(lis)
+	t.Cleanup(func() { srv.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return jiraiyav1.NewReleaseServiceClient(conn)
+}
+
+func TestGRPC(t *testing.T) {
+	env := setup(t)
+	c := grpcClient(t, env)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+env.newToken(t, "ci", service.RoleAdmin))
+
+	submit := func(version, from string, ids ...string) {
+		t.Helper()
+		req := &jiraiyav1.SubmitReleaseRequest{
+			Release: &jiraiyav1.Release{Version: version, FromVer: from, Platform: "ios", ReleaseDate: "2026-03-01"},
+		}
+		for _, id := range ids {
+			req.Changes = append(req.Changes, &jiraiyav1.Jira{Id: id, Title: id})
+		}
+		if _, err := c.SubmitRelease(ctx, req); err != nil {
+			t.Fatalf("submit %s: %v", version, err)
+		}
+	}
+
+	submit("1.0.0", "", "G-1")
+	watch, err := c.WatchReleases(ctx, &jiraiyav1.WatchReleasesRequest{Platform: "ios"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Let the watch pick its starting point so 1.0.0 is not sent.
+	time.Sleep(100 * time.Millisecond)
+	submit("1.1.0", "1.0.0", "G-2", "G-3")
+
+	t.Run("watch sends the new release", func(t *testing.T) {
+		e, err := watch.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Type != "release.submitted" || e.Release.GetVersion() != "1.1.0" || e.Actor != "ci" || len(e.Jiras) != 2 {
+			t.Fatalf("unexpected event %v", e)
+		}
+
+		// Resuming after that event sends the next one only.
+		resumed, err := c.WatchReleases(ctx, &jiraiyav1.WatchReleasesRequest{AfterId: e.Id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.DeleteRelease(ctx, &jiraiyav1.DeleteReleaseRequest{Version: "1.1.0"}); err != nil {
+			t.Fatal(err)
+		}
+		next, err := resumed.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.Id <= e.Id || next.Type != "release.deleted" {
+			t.Fatalf("expected the deletion after %d, got %v", e.Id, next)
+		}
+	})
+
+	t.Run("versions and jiras", func(t *testing.T) {
+		submit("1.2.0", "1.0.0", "G-4")
+		versions, err := c.GetVersions(ctx, &jiraiyav1.GetVersionsRequest{Platform: "ios"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if versions.Total != 2 {
+			t.Fatalf("expected 2 versions, got %v", versions)
+		}
+		jiras, err := c.GetJirasBetweenVersions(ctx, &jiraiyav1.GetJirasBetweenVersionsRequest{FromVersion: "1.0.0", ToVersion: "1.2.0"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jiras.Jiras) != 1 || jiras.Jiras[0].Id != "G-4" || jiras.Jiras[0].Release != "1.2.0" {
+			t.Fatalf("unexpected jiras %v", jiras.Jiras)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.SubmitRelease(ctx, &jiraiyav1.SubmitReleaseRequest{Release: &jiraiyav1.Release{Platform: "ios"}})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument, got %v", err)
+		}
+		_, err = c.DeleteRelease(ctx, &jiraiyav1.DeleteReleaseRequest{Version: "9.9.9"})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected NotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"jiraiya/internal/service"
+)
+
+func TestIdempotentSubmission(t *testing.T) {
+	env := setup(t)
+
+	payload := map[string]any{
+		"release": map[string]string{"version": "6.0.0", "from_ver": "", "platform": "ios", "submitted_by": "ci"},
+		"changes": []map[string]string{{"id": "K-1", "title": "First"}},
+	}
+	key := http.Header{"Idempotency-Key": {"build-6.0.0-1"}}
+
+	resp := env.do(t, http.MethodPut, "/api/releases", payload, key)
+	if resp.StatusCode != 200 {
+		t.Fatalf("first submit: expected 200, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Idempotent-Replayed") != "" {
+		t.Fatal("first submit should not be marked as replayed")
+	}
+	etag := resp.Header.Get("ETag")
+
+	resp = env.do(t, http.MethodPut, "/api/releases", payload, key)
+	if resp.StatusCode != 200 {
+		t.Fatalf("retry: expected 200, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Fatal("retry should be marked as replayed")
+	}
+	if resp.Header.Get("ETag") != etag {
+		t.Fatalf("retry ETag %s differs from original %s", resp.Header.Get("ETag"), etag)
+	}
+
+	// The replay must not have re-run the submission.
+	code, body := env.get(t, "/api/releases/6.0.0/history")
+	if code != 200 {
+		t.Fatalf("history: expected 200, got %d: %s", code, body)
+	}
+	if history := decode[[]any](t, body); len(history) != 1 {
+		t.Fatalf("expected 1 audit entry after replay, got %d", len(history))
+	}
+
+	t.Run("same key with different payload", func(t *testing.T) {
+		changed := map[string]any{
+			"release": payload["release"],
+			"changes": []map[string]string{{"id": "K-2"}},
+		}
+		resp := env.do(t, http.MethodPut, "/api/releases", changed, key)
+		if resp.StatusCode != http.StatusUnprocessableEntity {
+			t.Fatalf("expected 422, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestIdempotencyKeyExpiry(t *testing.T) {
+	env := setupWithConfig(t, service.Config{IdempotencyRetention: 50 * time.Millisecond})
+
+	payload := map[string]any{
+		"release": map[string]string{"version": "6.1.0", "platform": "ios"},
+		"changes": []map[string]string{{"id": "K-3"}},
+	}
+	key := http.Header{"Idempotency-Key": {"build-6.1.0-1"}}
+
+	if resp := env.do(t, http.MethodPut, "/api/releases", payload, key); resp.StatusCode != http.StatusOK {
+		t.Fatalf("first submit: expected 200, got %d", resp.StatusCode)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	// An expired key is not replayed even before the purge job has run.
+	resp := env.do(t, http.MethodPut, "/api/releases", payload, key)
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Idempotent-Replayed") != "" {
+		t.Fatalf("retry after expiry: expected a fresh 200, got %d replayed=%q", resp.StatusCode, resp.Header.Get("Idempotent-Replayed"))
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	n, err := env.svc.PurgeIdempotencyKeys(context.Background())
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("expected 1 purged key, got %d", n)
+	}
+}
@@ -21,13 +21,22 @@ import (
 	"jiraiya/sql/schema"
 )
 
-// testEnv holds the shared test infrastructure.
+// testEnv holds the shared test infrastructure. The HTTP helpers send token,
+// an admin token named "test", unless the request sets its own
+// Authorization header.
 type testEnv struct {
-	srv  *httptest.Server
-	pool *pgxpool.Pool
+	srv   *httptest.Server
+	pool  *pgxpool.Pool
+	svc   service.Service
+	token string
 }
 
 func setup(t *testing.T) *testEnv {
+	t.Helper()
+	return setupWithConfig(t, service.Config{})
+}
+
+func setupWithConfig(t *testing.T, cfg service.Config) *testEnv {
 	t.Helper()
 	ctx := context.Background()
 
@@ -57,12 +66,15 @@ func setup(t *testing.T) *testEnv {
 	}
 	t.Cleanup(func() { pool.Close() })
 
-	if _, err := pool.Exec(ctx, schema.InitSQL); err != nil {
-		t.Fatalf("apply schema: %v", err)
+	for i, m := range schema.Migrations {
+		if _, err := pool.Exec(ctx, m); err != nil {
+			t.Fatalf("apply schema %d: %v", i, err)
+		}
 	}
 
 	log := slog.New(slog.NewTextHandler(io.Discard, nil))
-	svc := service.New(pool, log)
+	svc := service.New(pool, log, cfg)
+	t.Cleanup(svc.Close)
 	if err := svc.LoadTrees(ctx); err != nil {
 		t.Fatalf("load trees: %v", err)
 	}
@@ -71,14 +83,59 @@ func setup(t *testing.T) *testEnv {
 	srv := httptest.NewServer(h.Routes())
 	t.Cleanup(func() { srv.Close() })
 
-	return &testEnv{srv: srv, pool: pool}
+	env := &testEnv{srv: srv, pool: pool, svc: svc}
+	env.token = env.newToken(t, "test", service.RoleAdmin)
+	return env
 }
 
 // helpers
 
+// newToken creates an API token for name with role.
+func (e *testEnv) newToken(t *testing.T, name, role string) string {
+	t.Helper()
+	tok, err := e.svc.CreateToken(context.Background(), service.TokenInput{Name: name, Role: role})
+	if err != nil {
+		t.Fatalf("create token %s: %v", name, err)
+	}
+	return tok.Token
+}
+
+// as returns a copy of e whose helpers call as name with role.
+func (e *testEnv) as(t *testing.T, name, role string) *testEnv {
+	t.Helper()
+	c := *e
+	c.token = e.newToken(t, name, role)
+	return &c
+}
+
+// withGrants returns a copy of e whose helpers call as name, holding only
+// grants.
+func (e *testEnv) withGrants(t *testing.T, name string, grants ...service.Grant) *testEnv {
+	t.Helper()
+	tok, err := e.svc.CreateToken(context.Background(), service.TokenInput{Name: name, Grants: grants})
+	if err != nil {
+		t.Fatalf("create token %s: %v", name, err)
+	}
+	c := *e
+	c.token = tok.Token
+	return &c
+}
+
+// send adds e's token to req unless it already carries credentials.
+func (e *testEnv) send(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Header["Authorization"]; !ok {
+		req.Header.Set("Authorization", "Bearer "+e.token)
+	}
+	return http.DefaultClient.Do(req)
+}
+
 func (e *testEnv) get(t *testing.T, path string) (int, []byte) {
 	t.Helper()
-	resp, err := http.Get(e.srv.URL + path)
+	req, err := http.NewRequest(http.MethodGet, e.srv.URL+path, nil)
+	if err != nil {
+		t.Fatalf("create GET %s: %v", path, err)
+	}
+	resp, err := e.send(req)
 	if err != nil {
 		t.Fatalf("GET %s: %v", path, err)
 	}
@@ -95,7 +152,7 @@ func (e *testEnv) put(t *testing.T, path string, payload any) (int, []byte) {
 		t.Fatalf("create PUT %s: %v", path, err)
 	}
 	resp.Header.Set("Content-Type", "application/json")
-	r, err := http.DefaultClient.Do(resp)
+	r, err := e.send(resp)
 	if err != nil {
 		t.Fatalf("PUT %s: %v", path, err)
 	}
@@ -110,7 +167,7 @@ func (e *testEnv) delete(t *testing.T, path string) (int, []byte) {
 	if err != nil {
 		t.Fatalf("create DELETE %s: %v", path, err)
 	}
-	r, err := http.DefaultClient.Do(req)
+	r, err := e.send(req)
 	if err != nil {
 		t.Fatalf("DELETE %s: %v", path, err)
 	}
@@ -119,6 +176,30 @@ func (e *testEnv) delete(t *testing.T, path string) (int, []byte) {
 	return r.StatusCode, body
 }
 
+func (e *testEnv) do(t *testing.T, method, path string, payload any, header http.Header) *http.Response {
+	t.Helper()
+	var body *bytes.Reader
+	if payload != nil {
+		data, _ := json.Marshal(payload)
+		body = bytes.NewReader(data)
+	} else {
+		body = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(method, e.srv.URL+path, body)
+	if err != nil {
+		t.Fatalf("create %s %s: %v", method, path, err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := e.send(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
 func decode[T any](t *testing.T, data []byte) T {
 	t.Helper()
 	var v T
@@ -244,7 +325,7 @@ func TestSubmitValidation(t *testing.T) {
 			t.Fatal(err)
 		}
 		resp.Header.Set("Content-Type", "application/json")
-		r, err := http.DefaultClient.Do(resp)
+		r, err := env.send(resp)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -259,7 +340,7 @@ func TestFullLifecycle(t *testing.T) {
 	env := setup(t)
 
 	// Submit first release (root)
-	code, body := env.put(t, "/api/releases", map[string]any{
+	code, body := env.as(t, "alice", service.RoleSubmitter).put(t, "/api/releases", map[string]any{
 		"release": map[string]string{
 			"version":      "1.0.0",
 			"from_ver":     "",
@@ -454,7 +535,7 @@ func TestUpsertRelease(t *testing.T) {
 	env := setup(t)
 
 	// Submit initial release
-	env.put(t, "/api/releases", map[string]any{
+	env.as(t, "alice", service.RoleSubmitter).put(t, "/api/releases", map[string]any{
 		"release": map[string]string{
 			"version": "2.0.0", "from_ver": "", "platform": "android",
 			"release_date": "2026-01-01", "submitted_by": "alice",
@@ -465,7 +546,7 @@ func TestUpsertRelease(t *testing.T) {
 	})
 
 	// Upsert same version with different jiras
-	code, body := env.put(t, "/api/releases", map[string]any{
+	code, body := env.as(t, "bob", service.RoleSubmitter).put(t, "/api/releases", map[string]any{
 		"release": map[string]string{
 			"version": "2.0.0", "from_ver": "", "platform": "android",
 			"release_date": "2026-01-02", "submitted_by": "bob",
@@ -0,0 +1,90 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestJiraCRUD(t *testing.T) {
+	env := setup(t)
+
+	code, body := env.put(t, "/api/releases", map[string]any{
+		"release": map[string]string{"version": "1.0.0", "platform": "ios"},
+		"changes": []map[string]string{
+			{"id": "J-1", "title": "Crash on login screen", "domain": "auth", "impact": "high", "relnotes": "Fixed a crash"},
+			{"id": "J-2", "title": "Slow checkout", "domain": "payments", "impact": "low", "relnotes": "Faster checkout flow"},
+			{"id": "J-3", "title": "Typo in settings", "domain": "auth", "impact": "low", "relnotes": "Corrected label"},
+		},
+	})
+	if code != 200 {
+		t.Fatalf("submit: expected 200, got %d: %s", code, body)
+	}
+
+	t.Run("get jira", func(t *testing.T) {
+		code, body := env.get(t, "/api/jiras/J-1")
+		if code != 200 {
+			t.Fatalf("expected 200, got %d: %s", code, body)
+		}
+		got := decode[map[string]any](t, body)
+		if got["title"] != "Crash on login screen" {
+			t.Fatalf("unexpected jira %v", got)
+		}
+		if releases := got["releases"].([]any); len(releases) != 1 || releases[0] != "1.0.0" {
+			t.Fatalf("expected releases [1.0.0], got %v", got["releases"])
+		}
+		if code, _ := env.get(t, "/api/jiras/J-404"); code != http.StatusNotFound {
+			t.Fatalf("missing jira: expected 404, got %d", code)
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		type page struct {
+			Jiras []map[string]string `json:"jiras"`
+			Total int                 `json:"total"`
+		}
+
+		_, body := env.get(t, "/api/jiras/search?q=crash")
+		if got := decode[page](t, body); got.Total != 1 || got.Jiras[0]["id"] != "J-1" {
+			t.Fatalf("q=crash: unexpected result %+v", got)
+		}
+
+		_, body = env.get(t, "/api/jiras/search?q=checkout")
+		if got := decode[page](t, body); got.Total != 1 || got.Jiras[0]["id"] != "J-2" {
+			t.Fatalf("q=checkout: unexpected result %+v", got)
+		}
+
+		_, body = env.get(t, "/api/jiras/search?domain=auth&impact=low")
+		if got := decode[page](t, body); got.Total != 1 || got.Jiras[0]["id"] != "J-3" {
+			t.Fatalf("domain+impact: unexpected result %+v", got)
+		}
+
+		_, body = env.get(t, "/api/jiras/search?limit=2&offset=2")
+		got := decode[page](t, body)
+		if got.Total != 3 || len(got.Jiras) != 1 || got.Jiras[0]["id"] != "J-3" {
+			t.Fatalf("pagination: unexpected result %+v", got)
+		}
+
+		if code, _ := env.get(t, "/api/jiras/search?limit=-1"); code != http.StatusBadRequest {
+			t.Fatalf("bad limit: expected 400, got %d", code)
+		}
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		resp := env.do(t, http.MethodPatch, "/api/jiras/J-2", map[string]string{"impact": "high"}, nil)
+		if resp.StatusCode != 200 {
+			t.Fatalf("patch: expected 200, got %d", resp.StatusCode)
+		}
+		_, body := env.get(t, "/api/jiras/J-2")
+		got := decode[map[string]any](t, body)
+		if got["impact"] != "high" || got["title"] != "Slow checkout" {
+			t.Fatalf("expected only impact to change, got %v", got)
+		}
+
+		if resp := env.do(t, http.MethodPatch, "/api/jiras/J-2", map[string]string{}, nil); resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("empty patch: expected 400, got %d", resp.StatusCode)
+		}
+		if resp := env.do(t, http.MethodPatch, "/api/jiras/J-404", map[string]string{"title": "x"}, nil); resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("missing jira: expected 404, got %d", resp.StatusCode)
+		}
+	})
+}
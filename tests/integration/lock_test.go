@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"jiraiya/internal/service"
+)
+
+func TestReleaseLock(t *testing.T) {
+	env := setupWithConfig(t, service.Config{AutoLockDays: 30})
+
+	submit := func(version, date string, header http.Header) int {
+		t.Helper()
+		resp := env.do(t, http.MethodPut, "/api/releases", map[string]any{
+			"release": map[string]string{"version": version, "platform": "ios", "release_date": date},
+			"changes": []map[string]string{{"id": "L-" + version}},
+		}, header)
+		return resp.StatusCode
+	}
+	override := http.Header{"X-Admin-Override": {"fix wrong jira"}}
+
+	t.Run("manual lock", func(t *testing.T) {
+		if code := submit("9.0.0", "", nil); code != 200 {
+			t.Fatalf("submit: expected 200, got %d", code)
+		}
+		if resp := env.do(t, http.MethodPut, "/api/releases/9.0.0/lock", nil, nil); resp.StatusCode != 200 {
+			t.Fatalf("lock: expected 200, got %d", resp.StatusCode)
+		}
+		if code := submit("9.0.0", "", nil); code != http.StatusLocked {
+			t.Fatalf("resubmit locked: expected 423, got %d", code)
+		}
+		if resp := env.do(t, http.MethodDelete, "/api/releases/9.0.0", nil, nil); resp.StatusCode != http.StatusLocked {
+			t.Fatalf("delete locked: expected 423, got %d", resp.StatusCode)
+		}
+		if resp := env.do(t, http.MethodDelete, "/api/releases/9.0.0/lock", nil, nil); resp.StatusCode != http.StatusLocked {
+			t.Fatalf("unlock without override: expected 423, got %d", resp.StatusCode)
+		}
+		if code := submit("9.0.0", "", override); code != 200 {
+			t.Fatalf("resubmit with override: expected 200, got %d", code)
+		}
+		if resp := env.do(t, http.MethodDelete, "/api/releases/9.0.0/lock", nil, override); resp.StatusCode != 200 {
+			t.Fatalf("unlock with override: expected 200, got %d", resp.StatusCode)
+		}
+		if code := submit("9.0.0", "", nil); code != 200 {
+			t.Fatalf("resubmit after unlock: expected 200, got %d", code)
+		}
+	})
+
+	t.Run("automatic lock after release date", func(t *testing.T) {
+		if code := submit("9.1.0", "2020-01-01", nil); code != 200 {
+			t.Fatalf("first submit: expected 200, got %d", code)
+		}
+		_, body := env.get(t, "/api/releases?version=9.1.0")
+		if got := decode[[]map[string]any](t, body); got[0]["locked"] != true {
+			t.Fatalf("expected release to report locked, got %v", got[0])
+		}
+		if code := submit("9.1.0", "2020-01-01", nil); code != http.StatusLocked {
+			t.Fatalf("resubmit old release: expected 423, got %d", code)
+		}
+		if resp := env.do(t, http.MethodDelete, "/api/releases/9.1.0/lock", nil, override); resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("unlock auto-locked release: expected 400, got %d", resp.StatusCode)
+		}
+		_, body = env.get(t, "/api/releases/9.1.0/history")
+		if strings.Contains(string(body), `"unlock"`) {
+			t.Fatalf("rejected unlock was audited: %s", body)
+		}
+		if resp := env.do(t, http.MethodDelete, "/api/releases/9.1.0", nil, override); resp.StatusCode != 200 {
+			t.Fatalf("delete with override: expected 200, got %d", resp.StatusCode)
+		}
+	})
+}
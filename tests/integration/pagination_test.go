@@ -0,0 +1,159 @@
+package integration
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestPagination(t *testing.T) {
+	env := setup(t)
+
+	// A linear chain 1.0.0 <- 1.1.0 <- ... <- 1.4.0 with descending dates, so
+	// version order and release date order disagree.
+	for i := 0; i < 5; i++ {
+		from := ""
+		if i > 0 {
+			from = fmt.Sprintf("1.%d.0", i-1)
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{
+				"version":      fmt.Sprintf("1.%d.0", i),
+				"from_ver":     from,
+				"platform":     "ios",
+				"release_date": fmt.Sprintf("2026-01-%02d", 10-i),
+			},
+			"changes": []map[string]string{{"id": fmt.Sprintf("P-%d", i)}},
+		})
+		if code != 200 {
+			t.Fatalf("submit: expected 200, got %d: %s", code, body)
+		}
+	}
+
+	// collect follows X-Next-Cursor until the last page.
+	collect := func(t *testing.T, path string) ([]string, string) {
+		t.Helper()
+		var versions []string
+		var total string
+		cursor := ""
+		for pages := 0; ; pages++ {
+			if pages > 10 {
+				t.Fatal("too many pages")
+			}
+			p := path
+			if cursor != "" {
+				p += "&cursor=" + url.QueryEscape(cursor)
+			}
+			resp := env.do(t, http.MethodGet, p, nil, nil)
+			if resp.StatusCode != 200 {
+				t.Fatalf("%s: expected 200, got %d", p, resp.StatusCode)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			for _, r := range decode[[]map[string]string](t, body) {
+				versions = append(versions, r["version"])
+			}
+			total = resp.Header.Get("X-Total-Count")
+			if cursor = resp.Header.Get("X-Next-Cursor"); cursor == "" {
+				return versions, total
+			}
+		}
+	}
+	expect := func(t *testing.T, got []string, want ...string) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		}
+	}
+
+	t.Run("releases by version", func(t *testing.T) {
+		got, total := collect(t, "/api/releases?platform=ios&limit=2")
+		expect(t, got, "1.0.0", "1.1.0", "1.2.0", "1.3.0", "1.4.0")
+		if total != "5" {
+			t.Fatalf("expected total 5, got %q", total)
+		}
+	})
+
+	t.Run("releases by date ascending", func(t *testing.T) {
+		got, _ := collect(t, "/api/releases?platform=ios&limit=2&sort=release_date&order=asc")
+		expect(t, got, "1.4.0", "1.3.0", "1.2.0", "1.1.0", "1.0.0")
+	})
+
+	t.Run("versions default order", func(t *testing.T) {
+		got, total := collect(t, "/api/versions?platform=ios&limit=3&since=2026-01-07")
+		expect(t, got, "1.0.0", "1.1.0", "1.2.0", "1.3.0")
+		if total != "4" {
+			t.Fatalf("expected total 4, got %q", total)
+		}
+	})
+
+	t.Run("undated releases sort last", func(t *testing.T) {
+		for i, date := range []string{"2026-02-01", "", "2026-02-03"} {
+			from := ""
+			if i > 0 {
+				from = fmt.Sprintf("2.%d.0", i-1)
+			}
+			code, body := env.put(t, "/api/releases", map[string]any{
+				"release": map[string]string{
+					"version":      fmt.Sprintf("2.%d.0", i),
+					"from_ver":     from,
+					"platform":     "android",
+					"release_date": date,
+				},
+				"changes": []map[string]string{{"id": fmt.Sprintf("Q-%d", i)}},
+			})
+			if code != 200 {
+				t.Fatalf("submit: expected 200, got %d: %s", code, body)
+			}
+		}
+		got, _ := collect(t, "/api/versions?platform=android&limit=1&order=asc")
+		expect(t, got, "2.0.0", "2.2.0", "2.1.0")
+		got, _ = collect(t, "/api/versions?platform=android&limit=1")
+		expect(t, got, "2.2.0", "2.0.0", "2.1.0")
+	})
+
+	t.Run("releases by creation time", func(t *testing.T) {
+		got, _ := collect(t, "/api/releases?platform=ios&limit=2&sort=created_at&order=desc")
+		expect(t, got, "1.4.0", "1.3.0", "1.2.0", "1.1.0", "1.0.0")
+	})
+
+	t.Run("field selection", func(t *testing.T) {
+		_, body := env.get(t, "/api/versions?platform=ios&limit=1&fields=version,status")
+		got := decode[[]map[string]any](t, body)
+		if len(got) != 1 || len(got[0]) != 2 || got[0]["version"] == nil || got[0]["status"] == nil {
+			t.Fatalf("expected only version and status, got %v", got)
+		}
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		resp := env.do(t, http.MethodGet, "/api/versions?platform=ios&limit=1", nil, nil)
+		cursor := resp.Header.Get("X-Next-Cursor")
+		for _, p := range []string{
+			"/api/versions?platform=ios&sort=title",
+			"/api/versions?platform=ios&order=up",
+			"/api/versions?platform=ios&cursor=garbage",
+			"/api/versions?platform=ios&order=asc&cursor=" + url.QueryEscape(cursor),
+		} {
+			if code, _ := env.get(t, p); code != http.StatusBadRequest {
+				t.Fatalf("%s: expected 400, got %d", p, code)
+			}
+		}
+	})
+
+	t.Run("tree subtree and depth", func(t *testing.T) {
+		_, body := env.get(t, "/api/admin/tree?platform=ios&root=1.2.0&depth=2")
+		tree := decode[map[string]any](t, body)
+		if tree["root"] != "1.2.0" || int(tree["node_count"].(float64)) != 2 {
+			t.Fatalf("unexpected subtree %v", tree)
+		}
+		if code, _ := env.get(t, "/api/admin/tree?platform=ios&root=9.9.9"); code != http.StatusNotFound {
+			t.Fatalf("unknown root: expected 404, got %d", code)
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestReleaseDates(t *testing.T) {
+	env := setup(t)
+
+	submit := func(version, from, date string) (int, []byte) {
+		return env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": version, "from_ver": from, "platform": "ios", "release_date": date},
+			"changes": []map[string]string{},
+		})
+	}
+
+	t.Run("rejects malformed date", func(t *testing.T) {
+		for _, date := range []string{"2024-9-1", "yesterday", "2024-02-30"} {
+			code, body := submit("7.0.0", "", date)
+			if code != 400 {
+				t.Fatalf("date %q: expected 400, got %d: %s", date, code, body)
+			}
+		}
+	})
+
+	for _, r := range []struct{ version, from, date string }{
+		{"7.0.0", "", "2024-09-01"},
+		{"7.1.0", "7.0.0", "2024-10-01"},
+		{"7.2.0", "7.1.0", "2024-11-15"},
+		{"7.3.0", "7.2.0", ""},
+	} {
+		if code, body := submit(r.version, r.from, r.date); code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	versions := func(path string) []string {
+		t.Helper()
+		code, body := env.get(t, path)
+		if code != 200 {
+			t.Fatalf("GET %s: expected 200, got %d: %s", path, code, body)
+		}
+		var out []string
+		for _, v := range decode[[]map[string]string](t, body) {
+			out = append(out, v["version"])
+		}
+		return out
+	}
+
+	t.Run("sorted chronologically, undated last", func(t *testing.T) {
+		got := versions("/api/versions?platform=ios")
+		want := []string{"7.2.0", "7.1.0", "7.0.0", "7.3.0"}
+		if len(got) != len(want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		}
+	})
+
+	t.Run("since and until window", func(t *testing.T) {
+		got := versions("/api/versions?platform=ios&since=2024-09-15&until=2024-10-31")
+		if len(got) != 1 || got[0] != "7.1.0" {
+			t.Fatalf("expected [7.1.0], got %v", got)
+		}
+	})
+
+	t.Run("invalid since", func(t *testing.T) {
+		if code, _ := env.get(t, "/api/versions?platform=ios&since=last-week"); code != 400 {
+			t.Fatalf("expected 400, got %d", code)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPartialReleaseEdits(t *testing.T) {
+	env := setup(t)
+
+	for _, r := range []struct {
+		version, from string
+		jiras         []string
+	}{
+		{"1.0.0", "", []string{"E-1"}},
+		{"1.1.0", "1.0.0", []string{"E-2", "E-3"}},
+	} {
+		changes := make([]map[string]string, len(r.jiras))
+		for i, id := range r.jiras {
+			changes[i] = map[string]string{"id": id}
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": r.version, "from_ver": r.from, "platform": "ios"},
+			"changes": changes,
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	diffIDs := func(t *testing.T) []string {
+		t.Helper()
+		code, body := env.get(t, "/api/jiras?from=1.0.0&to=1.1.0")
+		if code != 200 {
+			t.Fatalf("diff: expected 200, got %d: %s", code, body)
+		}
+		var ids []string
+		for _, j := range decode[[]map[string]string](t, body) {
+			ids = append(ids, j["id"])
+		}
+		return ids
+	}
+
+	t.Run("add jira", func(t *testing.T) {
+		resp := env.do(t, http.MethodPost, "/api/releases/1.1.0/jiras", map[string]string{"id": "E-4", "title": "Added later"}, nil)
+		if resp.StatusCode != 200 {
+			t.Fatalf("add: expected 200, got %d", resp.StatusCode)
+		}
+		if resp.Header.Get("ETag") == "" {
+			t.Fatal("expected ETag on add")
+		}
+		if ids := diffIDs(t); len(ids) != 3 {
+			t.Fatalf("expected 3 jiras after add, got %v", ids)
+		}
+	})
+
+	t.Run("remove jira", func(t *testing.T) {
+		resp := env.do(t, http.MethodDelete, "/api/releases/1.1.0/jiras/E-2", nil, nil)
+		if resp.StatusCode != 200 {
+			t.Fatalf("remove: expected 200, got %d", resp.StatusCode)
+		}
+		for _, id := range diffIDs(t) {
+			if id == "E-2" {
+				t.Fatal("E-2 should have been removed")
+			}
+		}
+		if resp := env.do(t, http.MethodDelete, "/api/releases/1.1.0/jiras/E-2", nil, nil); resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("remove twice: expected 404, got %d", resp.StatusCode)
+		}
+
+		_, body := env.get(t, "/api/releases/1.1.0/history")
+		history := decode[[]map[string]any](t, body)
+		last := history[len(history)-1]
+		if last["action"] != "remove_jira" || last["removed"].([]any)[0] != "E-2" {
+			t.Fatalf("unexpected last audit entry %v", last)
+		}
+	})
+
+	t.Run("patch metadata", func(t *testing.T) {
+		resp := env.do(t, http.MethodPatch, "/api/releases/1.1.0", map[string]string{"release_date": "2026-03-01"}, nil)
+		if resp.StatusCode != 200 {
+			t.Fatalf("patch: expected 200, got %d", resp.StatusCode)
+		}
+		_, body := env.get(t, "/api/releases?version=1.1.0")
+		got := decode[[]map[string]any](t, body)[0]
+		if got["release_date"] != "2026-03-01" || got["from_ver"] != "1.0.0" {
+			t.Fatalf("unexpected release after patch %v", got)
+		}
+		if ids := diffIDs(t); len(ids) != 2 {
+			t.Fatalf("patch must not touch jiras, got %v", ids)
+		}
+
+		if resp := env.do(t, http.MethodPatch, "/api/releases/1.1.0", map[string]string{"release_date": "March"}, nil); resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("bad date: expected 400, got %d", resp.StatusCode)
+		}
+		if resp := env.do(t, http.MethodPatch, "/api/releases/9.9.9", map[string]string{"submitted_by": "x"}, nil); resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("missing release: expected 404, got %d", resp.StatusCode)
+		}
+	})
+}
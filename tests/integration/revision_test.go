@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestJiraMetadataRevisions(t *testing.T) {
+	env := setup(t)
+
+	code, body := env.put(t, "/api/releases", map[string]any{
+		"release": map[string]string{"version": "4.0.0", "from_ver": "", "platform": "ios"},
+		"changes": []map[string]string{{"id": "R-1", "title": "Original", "domain": "core"}},
+	})
+	if code != 200 {
+		t.Fatalf("submit 4.0.0: expected 200, got %d: %s", code, body)
+	}
+	if got := decode[map[string]any](t, body); got["warnings"] != nil {
+		t.Fatalf("expected no warnings for new jira, got %v", got["warnings"])
+	}
+
+	code, body = env.put(t, "/api/releases", map[string]any{
+		"release": map[string]string{"version": "4.1.0", "from_ver": "4.0.0", "platform": "ios"},
+		"changes": []map[string]string{
+			{"id": "R-1", "title": "Updated", "domain": "core"},
+			{"id": "R-2", "title": "Second", "domain": "ui"},
+		},
+	})
+	if code != 200 {
+		t.Fatalf("submit 4.1.0: expected 200, got %d: %s", code, body)
+	}
+	got := decode[struct {
+		Warnings []map[string]string `json:"warnings"`
+	}](t, body)
+	if len(got.Warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", got.Warnings)
+	}
+	w := got.Warnings[0]
+	if w["jira_id"] != "R-1" || w["field"] != "title" || w["old"] != "Original" || w["new"] != "Updated" {
+		t.Fatalf("unexpected warning: %v", w)
+	}
+
+	// Re-submitting the old release rewrites R-1 globally.
+	code, body = env.put(t, "/api/releases", map[string]any{
+		"release": map[string]string{"version": "4.0.0", "from_ver": "", "platform": "ios"},
+		"changes": []map[string]string{{"id": "R-1", "title": "Rewritten", "domain": "core"}},
+	})
+	if code != 200 {
+		t.Fatalf("resubmit 4.0.0: expected 200, got %d: %s", code, body)
+	}
+
+	titles := func(path string) map[string]string {
+		t.Helper()
+		code, body := env.get(t, path)
+		if code != 200 {
+			t.Fatalf("GET %s: expected 200, got %d: %s", path, code, body)
+		}
+		out := map[string]string{}
+		for _, j := range decode[[]map[string]string](t, body) {
+			out[j["id"]] = j["title"]
+		}
+		return out
+	}
+
+	current := titles("/api/jiras?from=4.0.0&to=4.1.0")
+	if current["R-1"] != "Rewritten" {
+		t.Fatalf("expected current title Rewritten, got %q", current["R-1"])
+	}
+
+	asOf := titles("/api/jiras?from=4.0.0&to=4.1.0&as_of=to")
+	if asOf["R-1"] != "Updated" || asOf["R-2"] != "Second" {
+		t.Fatalf("expected historical titles, got %v", asOf)
+	}
+
+	if code, _ := env.get(t, "/api/jiras?from=4.0.0&to=4.1.0&as_of=bogus"); code != 400 {
+		t.Fatalf("expected 400 for invalid as_of, got %d", code)
+	}
+
+	t.Run("empty fields keep metadata until a PATCH clears them", func(t *testing.T) {
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": "4.1.0", "from_ver": "4.0.0", "platform": "ios"},
+			"changes": []map[string]string{{"id": "R-1"}, {"id": "R-2", "title": "Second"}},
+		})
+		if code != 200 {
+			t.Fatalf("resubmit 4.1.0: expected 200, got %d: %s", code, body)
+		}
+		if got := decode[map[string]any](t, body); got["warnings"] != nil {
+			t.Fatalf("expected no warnings for empty fields, got %v", got["warnings"])
+		}
+		_, body = env.get(t, "/api/jiras/R-2")
+		if r2 := decode[map[string]any](t, body); r2["domain"] != "ui" {
+			t.Fatalf("empty domain should keep the stored one, got %v", r2)
+		}
+
+		if resp := env.do(t, http.MethodPatch, "/api/jiras/R-2", map[string]string{"domain": ""}, nil); resp.StatusCode != 200 {
+			t.Fatalf("patch: expected 200, got %d", resp.StatusCode)
+		}
+		_, body = env.get(t, "/api/jiras/R-2")
+		if r2 := decode[map[string]any](t, body); r2["domain"] != "" || r2["title"] != "Second" {
+			t.Fatalf("expected PATCH to clear only domain, got %v", r2)
+		}
+	})
+}
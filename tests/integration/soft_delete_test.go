@@ -0,0 +1,117 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSoftDelete(t *testing.T) {
+	env := setup(t)
+
+	for _, r := range []struct{ version, from, jira string }{
+		{"1.0.0", "", "S-1"},
+		{"1.1.0", "1.0.0", "S-2"},
+	} {
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": r.version, "from_ver": r.from, "platform": "ios"},
+			"changes": []map[string]string{{"id": r.jira, "domain": "d-" + r.jira}},
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	t.Run("delete hides release", func(t *testing.T) {
+		if code, body := env.delete(t, "/api/releases/1.1.0"); code != 200 {
+			t.Fatalf("delete: expected 200, got %d: %s", code, body)
+		}
+		_, body := env.get(t, "/api/releases?version=1.1.0")
+		if got := decode[[]any](t, body); len(got) != 0 {
+			t.Fatalf("expected deleted release to be hidden, got %v", got)
+		}
+		_, body = env.get(t, "/api/versions?platform=ios")
+		if got := decode[[]map[string]string](t, body); len(got) != 1 {
+			t.Fatalf("expected 1 version, got %v", got)
+		}
+		_, body = env.get(t, "/api/filters?platform=ios")
+		if got := decode[map[string][]string](t, body); len(got["domains"]) != 1 {
+			t.Fatalf("expected deleted release's domains to be hidden, got %v", got)
+		}
+		if code, _ := env.delete(t, "/api/releases/1.1.0"); code != http.StatusNotFound {
+			t.Fatalf("delete twice: expected 404, got %d", code)
+		}
+	})
+
+	t.Run("restore brings back jira links", func(t *testing.T) {
+		resp := env.do(t, http.MethodPost, "/api/releases/1.1.0/restore", nil, nil)
+		if resp.StatusCode != 200 {
+			t.Fatalf("restore: expected 200, got %d", resp.StatusCode)
+		}
+		code, body := env.get(t, "/api/jiras?from=1.0.0&to=1.1.0")
+		if code != 200 {
+			t.Fatalf("diff: expected 200, got %d: %s", code, body)
+		}
+		if got := decode[[]map[string]string](t, body); len(got) != 1 || got[0]["id"] != "S-2" {
+			t.Fatalf("expected S-2 after restore, got %v", got)
+		}
+		if resp := env.do(t, http.MethodPost, "/api/releases/1.1.0/restore", nil, nil); resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("restore live release: expected 404, got %d", resp.StatusCode)
+		}
+
+		_, body = env.get(t, "/api/releases/1.1.0/history")
+		history := decode[[]map[string]any](t, body)
+		if n := len(history); n < 3 || history[n-2]["action"] != "delete" || history[n-1]["action"] != "restore" {
+			t.Fatalf("expected delete and restore in history, got %v", history)
+		}
+	})
+
+	t.Run("restore needs a live parent", func(t *testing.T) {
+		for _, v := range []string{"1.1.0", "1.0.0"} {
+			if code, body := env.delete(t, "/api/releases/"+v); code != 200 {
+				t.Fatalf("delete %s: expected 200, got %d: %s", v, code, body)
+			}
+		}
+		if resp := env.do(t, http.MethodPost, "/api/releases/1.1.0/restore", nil, nil); resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("restore under deleted parent: expected 400, got %d", resp.StatusCode)
+		}
+		stale := http.Header{"If-Match": {`"stale"`}}
+		if resp := env.do(t, http.MethodPost, "/api/releases/1.0.0/restore", nil, stale); resp.StatusCode != http.StatusPreconditionFailed {
+			t.Fatalf("restore with stale etag: expected 412, got %d", resp.StatusCode)
+		}
+		for _, v := range []string{"1.0.0", "1.1.0"} {
+			if resp := env.do(t, http.MethodPost, "/api/releases/"+v+"/restore", nil, nil); resp.StatusCode != 200 {
+				t.Fatalf("restore %s: expected 200, got %d", v, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("purge removes expired deletions", func(t *testing.T) {
+		if code, body := env.delete(t, "/api/releases/1.1.0"); code != 200 {
+			t.Fatalf("delete: expected 200, got %d: %s", code, body)
+		}
+
+		ctx := context.Background()
+		n, err := env.svc.PurgeDeletedReleases(ctx)
+		if err != nil {
+			t.Fatalf("purge: %v", err)
+		}
+		if n != 0 {
+			t.Fatalf("expected recent deletion to survive purge, purged %d", n)
+		}
+
+		if _, err := env.pool.Exec(ctx, "UPDATE releases SET deleted_at = now() - interval '31 days' WHERE version = '1.1.0'"); err != nil {
+			t.Fatalf("age deletion: %v", err)
+		}
+		n, err = env.svc.PurgeDeletedReleases(ctx)
+		if err != nil {
+			t.Fatalf("purge: %v", err)
+		}
+		if n != 1 {
+			t.Fatalf("expected 1 purged release, got %d", n)
+		}
+		if resp := env.do(t, http.MethodPost, "/api/releases/1.1.0/restore", nil, nil); resp.StatusCode != http.StatusNotFound {
+			t.Fatalf("restore purged release: expected 404, got %d", resp.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestReleaseLifecycle(t *testing.T) {
+	env := setup(t)
+
+	for _, r := range []struct {
+		version, from, status string
+		jiras                 []string
+	}{
+		{"8.0.0", "", "", []string{"S-1"}},
+		{"8.1.0-rc1", "8.0.0", "rc", []string{"S-2"}},
+		{"8.1.0", "8.1.0-rc1", "draft", []string{"S-3"}},
+	} {
+		changes := []map[string]string{}
+		for _, id := range r.jiras {
+			changes = append(changes, map[string]string{"id": id})
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": r.version, "from_ver": r.from, "platform": "ios", "status": r.status},
+			"changes": changes,
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", r.version, code, body)
+		}
+	}
+
+	transition := func(version, status string) int {
+		t.Helper()
+		resp := env.do(t, http.MethodPost, "/api/releases/"+version+"/transition", map[string]string{"status": status}, nil)
+		return resp.StatusCode
+	}
+
+	t.Run("status defaults to ga", func(t *testing.T) {
+		_, body := env.get(t, "/api/releases?version=8.0.0")
+		if got := decode[[]map[string]string](t, body); got[0]["status"] != "ga" {
+			t.Fatalf("expected ga, got %v", got[0]["status"])
+		}
+	})
+
+	t.Run("status cannot change through PUT", func(t *testing.T) {
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": "8.1.0", "from_ver": "8.1.0-rc1", "platform": "ios", "status": "ga"},
+			"changes": []map[string]string{{"id": "S-3"}},
+		})
+		if code != 400 {
+			t.Fatalf("expected 400, got %d: %s", code, body)
+		}
+	})
+
+	t.Run("transitions", func(t *testing.T) {
+		if code := transition("8.1.0", "ga"); code != 200 {
+			t.Fatalf("draft -> ga: expected 200, got %d", code)
+		}
+		if code := transition("8.1.0", "draft"); code != http.StatusConflict {
+			t.Fatalf("ga -> draft: expected 409, got %d", code)
+		}
+		if code := transition("8.1.0", "shipped"); code != 400 {
+			t.Fatalf("unknown status: expected 400, got %d", code)
+		}
+		if code := transition("9.9.9", "ga"); code != 404 {
+			t.Fatalf("missing release: expected 404, got %d", code)
+		}
+		if code := transition("8.1.0-rc1", "withdrawn"); code != 200 {
+			t.Fatalf("rc -> withdrawn: expected 200, got %d", code)
+		}
+	})
+
+	t.Run("versions filtered by status", func(t *testing.T) {
+		_, body := env.get(t, "/api/versions?platform=ios&status=ga")
+		got := decode[[]map[string]string](t, body)
+		if len(got) != 2 {
+			t.Fatalf("expected 2 ga versions, got %v", got)
+		}
+		_, body = env.get(t, "/api/versions?platform=ios&status=withdrawn,draft")
+		got = decode[[]map[string]string](t, body)
+		if len(got) != 1 || got[0]["version"] != "8.1.0-rc1" {
+			t.Fatalf("expected only the withdrawn rc, got %v", got)
+		}
+	})
+
+	t.Run("withdrawn node stays in tree", func(t *testing.T) {
+		_, body := env.get(t, "/api/admin/tree?platform=ios")
+		tree := decode[map[string]any](t, body)
+		if int(tree["node_count"].(float64)) != 3 {
+			t.Fatalf("expected 3 nodes, got %v", tree["node_count"])
+		}
+	})
+
+	t.Run("ga_only attribution", func(t *testing.T) {
+		_, body := env.get(t, "/api/jiras?from=8.0.0&to=8.1.0")
+		for _, j := range decode[[]map[string]string](t, body) {
+			if j["id"] == "S-2" && j["release"] != "8.1.0-rc1" {
+				t.Fatalf("S-2 attributed to %s, want 8.1.0-rc1", j["release"])
+			}
+		}
+
+		_, body = env.get(t, "/api/jiras?from=8.0.0&to=8.1.0&ga_only=true")
+		got := decode[[]map[string]string](t, body)
+		if len(got) != 2 {
+			t.Fatalf("expected 2 jiras, got %v", got)
+		}
+		for _, j := range got {
+			if j["release"] != "8.1.0" {
+				t.Fatalf("%s attributed to %s, want 8.1.0", j["id"], j["release"])
+			}
+		}
+	})
+
+	t.Run("transition recorded in history", func(t *testing.T) {
+		_, body := env.get(t, "/api/releases/8.1.0/history")
+		history := decode[[]map[string]any](t, body)
+		if last := history[len(history)-1]; last["action"] != "transition" {
+			t.Fatalf("expected transition entry, got %v", last)
+		}
+	})
+}
@@ -0,0 +1,274 @@
+package integration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"jiraiya/internal/service"
+)
+
+type receivedHook struct {
+	event     string
+	signature string
+	body      []byte
+}
+
+// hookReceiver records every webhook it is sent and fails requests to
+// /fail.
+type hookReceiver struct {
+	mu  sync.Mutex
+	got map[string][]receivedHook
+	srv *httptest.Server
+}
+
+func newHookReceiver(t *testing.T) *hookReceiver {
+	rcv := &hookReceiver{got: map[string][]receivedHook{}}
+	rcv.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rcv.mu.Lock()
+		rcv.got[r.URL.Path] = append(rcv.got[r.URL.Path], receivedHook{
+			event:     r.Header.Get("X-Jiraiya-Event"),
+			signature: r.Header.Get("X-Jiraiya-Signature"),
+			body:      body,
+		})
+		rcv.mu.Unlock()
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(rcv.srv.Close)
+	return rcv
+}
+
+func (rcv *hookReceiver) received(path string) []receivedHook {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return append([]receivedHook(nil), rcv.got[path]...)
+}
+
+func TestWebhooks(t *testing.T) {
+	env := setupWithConfig(t, service.Config{WebhookMaxAttempts: 2, WebhookBackoff: time.Millisecond})
+	rcv := newHookReceiver(t)
+	ctx := context.Background()
+
+	subscribe := func(platform, path string, events ...string) map[string]any {
+		t.Helper()
+		resp := env.do(t, http.MethodPost, "/api/webhooks", map[string]any{
+			"platform": platform,
+			"url":      rcv.srv.URL + path,
+			"secret":   "s3cret",
+			"events":   events,
+		}, nil)
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != 201 {
+			t.Fatalf("subscribe %s: expected 201, got %d: %s", path, resp.StatusCode, body)
+		}
+		hook := decode[map[string]any](t, body)
+		if _, ok := hook["secret"]; ok {
+			t.Fatalf("secret must not be returned: %s", body)
+		}
+		return hook
+	}
+	ok := subscribe("android", "/ok")
+	failing := subscribe("android", "/fail", "release.deleted")
+	subscribe("ios", "/ios")
+
+	resp := env.do(t, http.MethodPost, "/api/webhooks", map[string]any{"platform": "android", "url": "ftp://x", "secret": "s"}, nil)
+	if resp.StatusCode != 400 {
+		t.Fatalf("bad url: expected 400, got %d", resp.StatusCode)
+	}
+
+	submit := func(version, from string, jiras ...string) {
+		t.Helper()
+		changes := make([]map[string]string, len(jiras))
+		for i, id := range jiras {
+			changes[i] = map[string]string{"id": id, "title": "title " + id}
+		}
+		code, body := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": version, "from_ver": from, "platform": "android"},
+			"changes": changes,
+		})
+		if code != 200 {
+			t.Fatalf("submit %s: expected 200, got %d: %s", version, code, body)
+		}
+	}
+	submit("1.0", "", "W-1")
+	submit("1.1", "1.0", "W-2")
+	submit("1.1", "1.0", "W-3", "W-4")
+	if code, body := env.delete(t, "/api/releases/1.1"); code != 200 {
+		t.Fatalf("delete: expected 200, got %d: %s", code, body)
+	}
+
+	if _, err := env.svc.DeliverWebhooks(ctx); err != nil {
+		t.Fatalf("deliver webhooks: %v", err)
+	}
+
+	got := rcv.received("/ok")
+	want := []struct {
+		event, version string
+		jiras          []string
+	}{
+		{"release.submitted", "1.0", []string{"W-1"}},
+		{"release.submitted", "1.1", []string{"W-2"}},
+		{"release.updated", "1.1", []string{"W-3", "W-4"}},
+		{"release.deleted", "1.1", []string{"W-3", "W-4"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d deliveries, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		mac := hmac.New(sha256.New, []byte("s3cret"))
+		mac.Write(got[i].body)
+		if sig := "sha256=" + hex.EncodeToString(mac.Sum(nil)); got[i].signature != sig {
+			t.Fatalf("delivery %d: bad signature %q", i, got[i].signature)
+		}
+		var ev service.WebhookEvent
+		if err := json.Unmarshal(got[i].body, &ev); err != nil {
+			t.Fatalf("delivery %d: %v", i, err)
+		}
+		if got[i].event != w.event || ev.Type != w.event || ev.Release.Version != w.version {
+			t.Fatalf("delivery %d: got %s %s, want %s %s", i, ev.Type, ev.Release.Version, w.event, w.version)
+		}
+		ids := make([]string, len(ev.Jiras))
+		for j, jira := range ev.Jiras {
+			ids[j] = jira.ID
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(w.jiras) {
+			t.Fatalf("delivery %d: jiras %v, want %v", i, ids, w.jiras)
+		}
+		if ev.Jiras[0].Title != "title "+w.jiras[0] {
+			t.Fatalf("delivery %d: expected jira metadata, got %+v", i, ev.Jiras[0])
+		}
+	}
+	if ios := rcv.received("/ios"); len(ios) != 0 {
+		t.Fatalf("ios subscriber got %d android events", len(ios))
+	}
+
+	// The failing subscriber only listens for deletions; its delivery is
+	// retried once and then dead-lettered.
+	time.Sleep(50 * time.Millisecond)
+	if _, err := env.svc.DeliverWebhooks(ctx); err != nil {
+		t.Fatalf("deliver webhooks: %v", err)
+	}
+	if n := len(rcv.received("/fail")); n != 2 {
+		t.Fatalf("expected 2 attempts at the failing subscriber, got %d", n)
+	}
+
+	code, body := env.get(t, "/api/webhooks/dead-letters?platform=android")
+	if code != 200 {
+		t.Fatalf("dead letters: expected 200, got %d: %s", code, body)
+	}
+	dead := decode[[]service.WebhookDelivery](t, body)
+	if len(dead) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d: %s", len(dead), body)
+	}
+	if d := dead[0]; d.EventType != "release.deleted" || d.Attempts != 2 || d.LastStatusCode != 500 {
+		t.Fatalf("unexpected dead letter %+v", d)
+	}
+
+	code, body = env.get(t, fmt.Sprintf("/api/webhooks/%v/deliveries", ok["id"]))
+	if code != 200 {
+		t.Fatalf("delivery log: expected 200, got %d: %s", code, body)
+	}
+	entries := decode[[]service.WebhookDelivery](t, body)
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 logged deliveries, got %d", len(entries))
+	}
+	for _, d := range entries {
+		if d.Status != service.DeliverySucceeded || d.DeliveredAt == nil {
+			t.Fatalf("expected delivered, got %+v", d)
+		}
+	}
+
+	retry := fmt.Sprintf("/api/webhooks/deliveries/%d/retry", dead[0].ID)
+	if resp := env.do(t, http.MethodPost, retry, nil, nil); resp.StatusCode != 200 {
+		t.Fatalf("retry: expected 200, got %d", resp.StatusCode)
+	}
+	if resp := env.do(t, http.MethodPost, retry, nil, nil); resp.StatusCode != 404 {
+		t.Fatalf("retry of a pending delivery: expected 404, got %d", resp.StatusCode)
+	}
+	code, body = env.get(t, fmt.Sprintf("/api/webhooks/%v/deliveries?status=pending", failing["id"]))
+	if code != 200 || len(decode[[]service.WebhookDelivery](t, body)) != 1 {
+		t.Fatalf("expected the retried delivery to be pending, got %d: %s", code, body)
+	}
+
+	path := fmt.Sprintf("/api/webhooks/%v", failing["id"])
+	if code, _ := env.delete(t, path); code != 200 {
+		t.Fatalf("unsubscribe: expected 200, got %d", code)
+	}
+	if code, _ := env.get(t, path); code != 404 {
+		t.Fatalf("deleted subscription: expected 404, got %d", code)
+	}
+	if code, _ := env.get(t, path+"/deliveries"); code != 404 {
+		t.Fatalf("deleted subscription log: expected 404, got %d", code)
+	}
+}
+
+func TestEventLogLateCommit(t *testing.T) {
+	env := setup(t)
+	ctx := context.Background()
+
+	// Hold an event insert open the way enqueueReleaseEvent does.
+	tx, err := env.pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock_shared(hashtext('webhook_events'))"); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	var late int64
+	if err := tx.QueryRow(ctx, `INSERT INTO webhook_events (platform, event_type, release_version, payload)
+		VALUES ('ios', 'release.submitted', '0.9.0', '{}') RETURNING id`).Scan(&late); err != nil {
+		t.Fatalf("insert event: %v", err)
+	}
+
+	submitted := make(chan int, 1)
+	go func() {
+		code, _ := env.put(t, "/api/releases", map[string]any{
+			"release": map[string]string{"version": "1.0.0", "platform": "android"},
+			"changes": []map[string]string{{"id": "LC-1"}},
+		})
+		submitted <- code
+	}()
+	select {
+	case code := <-submitted:
+		if code != 200 {
+			t.Fatalf("submit: expected 200, got %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("submit waited for an unrelated event insert")
+	}
+
+	listed := make(chan []service.ReleaseEvent, 1)
+	go func() {
+		events, err := env.svc.ListReleaseEvents(ctx, service.EventQuery{AfterID: late - 1, Limit: 10})
+		if err != nil {
+			t.Errorf("list events: %v", err)
+		}
+		listed <- events
+	}()
+	select {
+	case events := <-listed:
+		t.Fatalf("read passed an uncommitted event: %v", events)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	events := <-listed
+	if len(events) != 2 || events[0].ID != late || events[1].Release.Version != "1.0.0" {
+		t.Fatalf("expected the late event before the new one, got %v", events)
+	}
+}